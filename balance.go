@@ -0,0 +1,194 @@
+package boxpacker3
+
+import "math"
+
+// balanceTolerance absorbs floating point noise when comparing axle loads and the centre of gravity against limits.
+const balanceTolerance = 1e-9
+
+// Axle is a support of a container or truck loading area.
+//
+// Axles stand across the length of the box, which is its width: the front of the loading area
+// is at x = 0 and the rear at x = width. Heavy items are usually placed over the axles.
+type Axle struct {
+	// Position is the distance of the axle from the front of the box.
+	Position float64 `json:"position"`
+	// MaxLoad is the maximum weight of the cargo the axle may carry.
+	MaxLoad float64 `json:"maxLoad"`
+}
+
+// WithAxles enables the container loading mode with a front and a rear axle (or axle group).
+//
+// The cargo weight is shared between the two axles by the lever rule: the closer the centre of gravity
+// is to an axle, the more of the weight the axle carries. An item is rejected when it would overload
+// one of the axles, or lift one because the centre of gravity leaves the wheelbase. Only the cargo counts,
+// the tare of the vehicle is not included. Loading may start over the front axle.
+func WithAxles(front, rear Axle) BoxOption {
+	return func(b *Box) {
+		if rear.Position < front.Position {
+			front, rear = rear, front
+		}
+
+		b.axles = []Axle{front, rear}
+		b.addBalancePoints()
+	}
+}
+
+// WithCenterOfGravityLimits sets the envelope, from the min to the max corner, the centre of gravity
+// of the cargo has to stay in.
+//
+// An item is rejected when it would move the centre of gravity out of the envelope, so the load is within it
+// after every item, the first one included. Loading may start from the floor below the min corner.
+func WithCenterOfGravityLimits(minCorner, maxCorner Pivot) BoxOption {
+	return func(b *Box) {
+		for axis := range minCorner {
+			minCorner[axis], maxCorner[axis] = min(minCorner[axis], maxCorner[axis]), max(minCorner[axis], maxCorner[axis])
+		}
+
+		b.cogLimits = &[2]Pivot{minCorner, maxCorner}
+		b.addBalancePoints()
+	}
+}
+
+// GetAxles returns the front and rear axle of the box, or nil when the box has no axles.
+func (b *Box) GetAxles() []Axle {
+	return append([]Axle(nil), b.axles...)
+}
+
+// GetCenterOfGravityLimits returns the min and max corner of the centre of gravity envelope.
+// The last result is false when the box has no envelope.
+func (b *Box) GetCenterOfGravityLimits() (Pivot, Pivot, bool) {
+	if b.cogLimits == nil {
+		return Pivot{}, Pivot{}, false
+	}
+
+	return b.cogLimits[0], b.cogLimits[1], true
+}
+
+// GetCenterOfGravity returns the centre of gravity of the items in the box.
+// Each item is considered to have its weight in its geometric centre.
+// The result is the zero Pivot when the items weigh nothing.
+func (b *Box) GetCenterOfGravity() Pivot {
+	return centerOfGravity(b.items, nil)
+}
+
+// GetAxleLoads returns the cargo weight carried by the front and the rear axle of the box,
+// or nil when the box has no axles.
+func (b *Box) GetAxleLoads() []float64 {
+	if len(b.axles) == 0 {
+		return nil
+	}
+
+	return axleLoads(b.axles, b.itemsWeight, b.GetCenterOfGravity())
+}
+
+// keepsBalance reports whether placing the item keeps the axle loads and the centre of gravity within the limits.
+// An axle load below zero means the centre of gravity is outside the wheelbase.
+func (b *Box) keepsBalance(item *Item) bool {
+	if len(b.axles) == 0 && b.cogLimits == nil {
+		return true
+	}
+
+	weight := b.itemsWeight + item.weight
+	if weight <= 0 {
+		return true
+	}
+
+	cog := centerOfGravity(b.items, item)
+
+	for i, load := range axleLoads(b.axles, weight, cog) {
+		if load > b.axles[i].MaxLoad+balanceTolerance || load < -balanceTolerance {
+			return false
+		}
+	}
+
+	return b.cogLimits == nil || b.cogDistance(cog) <= balanceTolerance
+}
+
+// addBalancePoints adds the floor pivots a balanced load can start from to the extreme points:
+// above the front axle and below the min corner of the centre of gravity envelope.
+// A load started at the origin would often be off balance from its first item.
+func (b *Box) addBalancePoints() {
+	var points []Pivot
+
+	if len(b.axles) > 0 {
+		points = append(points, Pivot{max(b.axles[0].Position, 0), 0, 0})
+	}
+
+	if b.cogLimits != nil {
+		points = append(points, Pivot{max(b.cogLimits[0][WidthAxis], 0), 0, max(b.cogLimits[0][DepthAxis], 0)})
+	}
+
+	for _, p := range points {
+		if p != (Pivot{}) {
+			b.addExtremePoint(p)
+		}
+	}
+}
+
+// cogDistance returns the distance from the point to the centre of gravity envelope, 0 inside it.
+func (b *Box) cogDistance(p Pivot) float64 {
+	var sum float64
+
+	for axis := range p {
+		d := max(b.cogLimits[0][axis]-p[axis], p[axis]-b.cogLimits[1][axis], 0)
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// centerOfGravity returns the weighted centre of the items and the extra item, if any.
+func centerOfGravity(items []*Item, extra *Item) Pivot {
+	var (
+		moment Pivot
+		weight float64
+	)
+
+	add := func(it *Item) {
+		if it == nil || it.weight == 0 {
+			return
+		}
+
+		dim := it.GetDimension()
+
+		for axis := range moment {
+			moment[axis] += it.weight * (it.position[axis] + dim[axis]/2) //nolint:mnd // geometric centre
+		}
+
+		weight += it.weight
+	}
+
+	for _, it := range items {
+		add(it)
+	}
+
+	add(extra)
+
+	if weight <= 0 {
+		return Pivot{}
+	}
+
+	for axis := range moment {
+		moment[axis] /= weight
+	}
+
+	return moment
+}
+
+// axleLoads shares the weight with the centre of gravity cog between the front and the rear axle by the lever rule.
+// A load is negative when the centre of gravity is outside the wheelbase and the axle is lifted.
+func axleLoads(axles []Axle, weight float64, cog Pivot) []float64 {
+	if len(axles) == 0 {
+		return nil
+	}
+
+	front, rear := axles[0].Position, axles[1].Position
+
+	if rear-front <= balanceTolerance {
+		return []float64{weight / 2, weight / 2} //nolint:mnd // both axles at the same position
+	}
+
+	rearLoad := weight * (cog[WidthAxis] - front) / (rear - front)
+
+	return []float64{weight - rearLoad, rearLoad}
+}
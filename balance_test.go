@@ -0,0 +1,202 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestBox_AxleLoads tests that the cargo weight is shared between the axles by the lever rule.
+func TestBox_AxleLoads(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithAxles(boxpacker3.Axle{Position: 800, MaxLoad: 1000}, boxpacker3.Axle{Position: 200, MaxLoad: 1000}))
+
+	require.Equal(t, []boxpacker3.Axle{{Position: 200, MaxLoad: 1000}, {Position: 800, MaxLoad: 1000}}, box.GetAxles())
+	require.Equal(t, []float64{0, 0}, box.GetAxleLoads())
+
+	require.True(t, box.PutItem(boxpacker3.NewItem("middle", 100, 100, 100, 100), boxpacker3.Pivot{450, 0, 0}))
+	require.InDeltaSlice(t, []float64{50, 50}, box.GetAxleLoads(), 1e-9)
+
+	require.True(t, box.PutItem(boxpacker3.NewItem("front", 100, 100, 100, 100), boxpacker3.Pivot{}))
+	require.InDeltaSlice(t, []float64{175, 25}, box.GetAxleLoads(), 1e-9)
+	cog := box.GetCenterOfGravity()
+	require.InDeltaSlice(t, []float64{275, 50, 50}, cog[:], 1e-9)
+
+	require.Nil(t, boxpacker3.NewBox("box", 10, 10, 10, 10).GetAxleLoads())
+}
+
+// TestBox_PutItem_AxleLimit tests that items overloading an axle are rejected.
+func TestBox_PutItem_AxleLimit(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithAxles(boxpacker3.Axle{Position: 200, MaxLoad: 100}, boxpacker3.Axle{Position: 800, MaxLoad: 1000}))
+
+	require.True(t, box.PutItem(boxpacker3.NewItem("middle", 100, 100, 100, 100), boxpacker3.Pivot{450, 0, 0}))
+	require.False(t, box.PutItem(boxpacker3.NewItem("front", 100, 100, 100, 100), boxpacker3.Pivot{}),
+		"The front axle would carry 175")
+	require.True(t, box.PutItem(boxpacker3.NewItem("rear", 100, 100, 100, 100), boxpacker3.Pivot{900, 0, 0}))
+	require.InDeltaSlice(t, []float64{25, 175}, box.GetAxleLoads(), 1e-9)
+}
+
+// TestBox_PutItem_CenterOfGravity tests that items moving the centre of gravity out of the envelope are rejected.
+func TestBox_PutItem_CenterOfGravity(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{600, 100, 100}, boxpacker3.Pivot{400, 0, 0}))
+
+	minCorner, maxCorner, ok := box.GetCenterOfGravityLimits()
+	require.True(t, ok)
+	require.Equal(t, boxpacker3.Pivot{400, 0, 0}, minCorner)
+	require.Equal(t, boxpacker3.Pivot{600, 100, 100}, maxCorner)
+
+	require.True(t, box.PutItem(boxpacker3.NewItem("middle", 100, 100, 100, 100), boxpacker3.Pivot{450, 0, 0}))
+	require.False(t, box.PutItem(boxpacker3.NewItem("front", 100, 100, 100, 100), boxpacker3.Pivot{}))
+	require.False(t, box.PutItem(boxpacker3.NewItem("rear", 100, 100, 100, 100), boxpacker3.Pivot{900, 0, 0}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("light", 100, 100, 100, 10), boxpacker3.Pivot{900, 0, 0}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("next", 100, 100, 100, 100), boxpacker3.Pivot{550, 0, 0}))
+
+	_, _, ok = boxpacker3.NewBox("box", 10, 10, 10, 10).GetCenterOfGravityLimits()
+	require.False(t, ok)
+}
+
+// TestBox_PutItem_CenterOfGravity_First tests that the first item has to keep the centre of gravity in the envelope
+// and may be loaded from the floor below the min corner.
+func TestBox_PutItem_CenterOfGravity_First(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{400, 0, 0}, boxpacker3.Pivot{600, 100, 100}))
+
+	require.Equal(t, []boxpacker3.Pivot{{}, {400, 0, 0}}, box.GetExtremePoints())
+	require.False(t, box.PutItem(boxpacker3.NewItem("front", 100, 100, 100, 100), boxpacker3.Pivot{}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("first", 100, 100, 100, 100), boxpacker3.Pivot{400, 0, 0}))
+
+	boxes := []*boxpacker3.Box{boxpacker3.NewBox("box", 100, 10, 10, 1000,
+		boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{40, 0, 0}, boxpacker3.Pivot{60, 10, 10}))}
+	items := []*boxpacker3.Item{boxpacker3.NewItem("item", 10, 10, 10, 10)}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+	require.InDelta(t, 40.0, result.Boxes[0].GetItems()[0].GetPosition()[boxpacker3.WidthAxis], 1e-9)
+}
+
+// TestBox_PutItem_AxleLifted tests that items moving the centre of gravity outside the wheelbase are rejected.
+func TestBox_PutItem_AxleLifted(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithAxles(boxpacker3.Axle{Position: 200, MaxLoad: 1000}, boxpacker3.Axle{Position: 800, MaxLoad: 1000}))
+
+	require.False(t, box.PutItem(boxpacker3.NewItem("rear", 100, 100, 100, 100), boxpacker3.Pivot{900, 0, 0}),
+		"The front axle would be lifted")
+	require.True(t, box.PutItem(boxpacker3.NewItem("middle", 100, 100, 100, 300), boxpacker3.Pivot{450, 0, 0}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("rear", 100, 100, 100, 100), boxpacker3.Pivot{900, 0, 0}))
+}
+
+// TestPacker_Container tests that a container is loaded within its axle and centre of gravity limits.
+func TestPacker_Container(t *testing.T) {
+	t.Parallel()
+
+	container := boxpacker3.NewBox("40ft", 1200, 240, 240, 30000,
+		boxpacker3.WithAxles(boxpacker3.Axle{Position: 100, MaxLoad: 9000}, boxpacker3.Axle{Position: 1100, MaxLoad: 9000}),
+		boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{500, 0, 0}, boxpacker3.Pivot{700, 120, 240}))
+
+	items := make([]*boxpacker3.Item, 0, 40)
+	for i := range 40 {
+		items = append(items, boxpacker3.NewItem("crate-"+strconv.Itoa(i), 120, 80, 100, 500))
+	}
+
+	boxes := []*boxpacker3.Box{container}
+
+	for _, strategy := range []boxpacker3.PackingStrategy{
+		boxpacker3.StrategyMinimizeBoxes,
+		boxpacker3.StrategyBestFit,
+		boxpacker3.StrategyNextFit,
+	} {
+		packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy))
+		result, err := packer.PackCtx(context.Background(), boxes, items)
+		require.NoError(t, err)
+		require.Len(t, result.Boxes, 1)
+		require.Empty(t, boxpacker3.ValidateResult(boxes, items, result), strategy.String())
+
+		packed := result.Boxes[0]
+		require.Len(t, packed.GetItems(), 35, "Two axles carry 18000, and the load stays balanced after every crate")
+
+		for _, load := range packed.GetAxleLoads() {
+			require.LessOrEqual(t, load, 9000.0)
+		}
+
+		for _, it := range result.UnfitItems {
+			require.Equal(t, boxpacker3.UnfitReasonNoSpace, it.GetUnfitReason())
+		}
+	}
+}
+
+// TestValidateResult_Balance tests that overloaded axles and an off-balance load are reported.
+func TestValidateResult_Balance(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000)
+	item := boxpacker3.NewItem("front", 100, 100, 100, 100)
+	require.True(t, box.PutItem(item, boxpacker3.Pivot{}))
+
+	boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{400, 0, 0}, boxpacker3.Pivot{600, 100, 100})(box)
+	boxpacker3.WithAxles(boxpacker3.Axle{Position: 200, MaxLoad: 50}, boxpacker3.Axle{Position: 800, MaxLoad: 50})(box)
+
+	result := &boxpacker3.Result{Boxes: []*boxpacker3.Box{box}}
+	violations := boxpacker3.ValidateResult([]*boxpacker3.Box{box}, []*boxpacker3.Item{item}, result)
+
+	types := make([]boxpacker3.ViolationType, 0, len(violations))
+	for _, v := range violations {
+		types = append(types, v.Type)
+	}
+
+	// The front axle carries 125, the rear one is lifted.
+	require.ElementsMatch(t, []boxpacker3.ViolationType{
+		boxpacker3.ViolationAxleOverload,
+		boxpacker3.ViolationAxleOverload,
+		boxpacker3.ViolationCenterOfGravity,
+	}, types)
+	require.Equal(t, "AxleOverload", boxpacker3.ViolationAxleOverload.String())
+	require.Equal(t, "CenterOfGravity", boxpacker3.ViolationCenterOfGravity.String())
+}
+
+// TestBox_JSON_Balance tests that the axles, the envelope and the reported loads survive a JSON round trip.
+func TestBox_JSON_Balance(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("truck", 1000, 100, 100, 10000,
+		boxpacker3.WithAxles(boxpacker3.Axle{Position: 200, MaxLoad: 1000}, boxpacker3.Axle{Position: 800, MaxLoad: 1000}),
+		boxpacker3.WithCenterOfGravityLimits(boxpacker3.Pivot{400, 0, 0}, boxpacker3.Pivot{600, 100, 100}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("middle", 100, 100, 100, 100), boxpacker3.Pivot{550, 0, 0}))
+
+	data, err := json.Marshal(box)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"centerOfGravity":[600,50,50]`)
+	require.Contains(t, string(data), `"axleLoads":[`)
+
+	var restored boxpacker3.Box
+	require.NoError(t, json.Unmarshal(data, &restored))
+	require.Equal(t, box.GetAxles(), restored.GetAxles())
+	require.InDeltaSlice(t, box.GetAxleLoads(), restored.GetAxleLoads(), 1e-9)
+
+	minCorner, maxCorner, ok := restored.GetCenterOfGravityLimits()
+	require.True(t, ok)
+	require.Equal(t, boxpacker3.Pivot{400, 0, 0}, minCorner)
+	require.Equal(t, boxpacker3.Pivot{600, 100, 100}, maxCorner)
+
+	plain, err := json.Marshal(boxpacker3.NewBox("box", 10, 10, 10, 10))
+	require.NoError(t, err)
+	require.NotContains(t, string(plain), "axle")
+}
@@ -236,3 +236,62 @@ func BenchmarkPacker_StrategyComparison(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkPacker_FillRate reports the fill rate and the number of used boxes of every strategy
+// on the seeded datasets (see packSeeded).
+//
+// Besides timings, the benchmark reports the "fill%" and "boxes" metrics. They are the same on every run,
+// so placement changes can be compared before and after; TestExtremePoints_FillRate keeps the comparison
+// with the corner pivots extreme points replaced.
+func BenchmarkPacker_FillRate(b *testing.B) {
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		b.Run(strategy.String(), func(b *testing.B) {
+			var (
+				used int
+				fill float64
+			)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for range b.N {
+				used, fill = packSeeded(strategy)
+			}
+
+			b.ReportMetric(fill, "fill%")
+			b.ReportMetric(float64(used), "boxes")
+		})
+	}
+}
+
+// fillRateSeeds is the number of seeded datasets of 100 items, with the sizes of generateItems,
+// used to compare placement changes.
+const fillRateSeeds = 30
+
+// packSeeded packs every seeded dataset into the default box list and returns the number of used boxes
+// and their fill rate in percent, i.e. the volume of the packed items over the volume of the used boxes.
+func packSeeded(strategy boxpacker3.PackingStrategy) (int, float64) {
+	packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy))
+
+	var (
+		used                     int
+		itemsVolume, boxesVolume float64
+	)
+
+	for seed := uint64(1); seed <= fillRateSeeds; seed++ {
+		for _, box := range packer.Pack(NewDefaultBoxList(), seededItems(seed, 100, 10, 160, false)).Boxes {
+			if len(box.GetItems()) == 0 {
+				continue
+			}
+
+			used++
+			boxesVolume += box.GetVolume()
+
+			for _, item := range box.GetItems() {
+				itemsVolume += item.GetVolume()
+			}
+		}
+	}
+
+	return used, itemsVolume / boxesVolume * 100 //nolint:mnd // percent
+}
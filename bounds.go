@@ -0,0 +1,190 @@
+package boxpacker3
+
+import (
+	"math"
+	"sort"
+)
+
+// VolumeLowerBound returns the continuous lower bound on the number of boxes of the given type
+// needed for the items: the total volume of the items divided by the volume of the box, rounded up.
+//
+// Items that do not fit into the box in any allowed rotation, or are heavier than its maximum weight,
+// cannot be packed and are ignored by every lower bound.
+func VolumeLowerBound(box *Box, items []*Item) int {
+	if box == nil || box.volume <= 0 {
+		return 0
+	}
+
+	var volume float64
+
+	for _, it := range packable(box, items) {
+		volume += it.volume
+	}
+
+	return ceilBound(volume / box.volume)
+}
+
+// WeightLowerBound returns the number of boxes of the given type needed at least to carry the weight of the items.
+func WeightLowerBound(box *Box, items []*Item) int {
+	if box == nil || box.maxWeight <= 0 || math.IsInf(box.maxWeight, 1) {
+		return 0
+	}
+
+	var weight float64
+
+	for _, it := range packable(box, items) {
+		weight += it.weight
+	}
+
+	return ceilBound(weight / box.maxWeight)
+}
+
+// MPVLowerBound returns a Martello-Pisinger-Vigo style lower bound for three-dimensional bin packing.
+//
+// For every pair of box sides, items that are longer than half of both sides in every allowed rotation
+// cannot stand next to each other in that plane, so they have to be stacked along the third side.
+// Their lengths along the third side form a one-dimensional bin packing problem, which is bounded with
+// the Martello-Toth L2 bound. The best of the three bounds is returned. Unlike the volume bound,
+// it accounts for items that waste space because they are just over half as large as the box.
+func MPVLowerBound(box *Box, items []*Item) int {
+	if box == nil {
+		return 0
+	}
+
+	items = packable(box, items)
+	size := [3]float64{box.width, box.height, box.depth}
+	best := 0
+
+	for c := WidthAxis; c <= DepthAxis; c++ {
+		a, b := (c+1)%3, (c+2)%3 //nolint:mnd
+
+		lengths := make([]float64, 0, len(items))
+
+		for _, it := range items {
+			if length, ok := stackedLength(it, size, a, b, c); ok {
+				lengths = append(lengths, length)
+			}
+		}
+
+		best = max(best, martelloTothL2(lengths, size[c]))
+	}
+
+	return best
+}
+
+// LowerBound returns the best of VolumeLowerBound, WeightLowerBound and MPVLowerBound.
+func LowerBound(box *Box, items []*Item) int {
+	return max(VolumeLowerBound(box, items), WeightLowerBound(box, items), MPVLowerBound(box, items))
+}
+
+// OptimalityGap returns how far the number of used boxes is from LowerBound for the packed items,
+// relative to the bound: 0 means the result is optimal, 0.25 means it uses 25% more boxes than the bound.
+// The bound is computed for the given box type, so the gap is meaningful when all boxes are of that type.
+func (r *Result) OptimalityGap(box *Box) float64 {
+	var packed []*Item
+
+	for _, b := range r.Boxes {
+		if b != nil {
+			packed = append(packed, b.items...)
+		}
+	}
+
+	used := countUsedBoxes(r.Boxes)
+
+	bound := LowerBound(box, packed)
+	if bound == 0 {
+		return 0
+	}
+
+	return float64(used-bound) / float64(bound)
+}
+
+// packable returns the items that fit into the empty box.
+func packable(box *Box, items []*Item) []*Item {
+	empty := emptyCopies([]*Box{box})
+	result := make([]*Item, 0, len(items))
+
+	for _, it := range items {
+		if it != nil && fitsAnyEmpty(empty, it) {
+			result = append(result, it)
+		}
+	}
+
+	return result
+}
+
+// stackedLength reports whether the item is longer than half of sides a and b in every allowed rotation
+// that fits into the box, and returns its shortest length along side c among those rotations.
+func stackedLength(item *Item, size [3]float64, a, b, c Axis) (float64, bool) {
+	length := math.Inf(1)
+
+	for rt := RotationTypeWhd; rt <= RotationTypeWdh; rt++ {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
+		matrix := rotationMatrix[rt]
+		dim := [3]float64{item.whd[matrix[WidthAxis]], item.whd[matrix[HeightAxis]], item.whd[matrix[DepthAxis]]}
+
+		if dim[WidthAxis] > size[WidthAxis] || dim[HeightAxis] > size[HeightAxis] || dim[DepthAxis] > size[DepthAxis] {
+			continue
+		}
+
+		if dim[a] <= size[a]/2 || dim[b] <= size[b]/2 {
+			return 0, false
+		}
+
+		length = min(length, dim[c])
+	}
+
+	return length, !math.IsInf(length, 1)
+}
+
+// martelloTothL2 returns the L2 lower bound of Martello and Toth for one-dimensional bin packing.
+func martelloTothL2(sizes []float64, capacity float64) int {
+	if len(sizes) == 0 || capacity <= 0 {
+		return 0
+	}
+
+	sort.Float64s(sizes)
+
+	half := capacity / 2 //nolint:mnd
+	alphas := []float64{0}
+
+	for _, s := range sizes {
+		if s <= half {
+			alphas = append(alphas, s)
+		}
+	}
+
+	best := 0
+
+	for _, alpha := range alphas {
+		var (
+			large, medium       int
+			mediumSum, smallSum float64
+		)
+
+		for _, s := range sizes {
+			switch {
+			case s > capacity-alpha:
+				large++
+			case s > half:
+				medium++
+				mediumSum += s
+			case s >= alpha:
+				smallSum += s
+			}
+		}
+
+		free := float64(medium)*capacity - mediumSum
+		best = max(best, large+medium+max(0, ceilBound((smallSum-free)/capacity)))
+	}
+
+	return best
+}
+
+// ceilBound rounds a bound up, ignoring floating point noise.
+func ceilBound(v float64) int {
+	return int(math.Ceil(v - epsilon))
+}
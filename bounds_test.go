@@ -0,0 +1,75 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestLowerBounds tests the volume, weight and MPV bounds on hand-checked inputs.
+func TestLowerBounds(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 10, 10, 100)
+
+	// Eight cubes that are just over half of every side: any two of them collide,
+	// so each needs its own box although they fill only 17% of the volume each.
+	big := make([]*boxpacker3.Item, 0, 8)
+	for i := range 8 {
+		big = append(big, boxpacker3.NewItem("big-"+strconv.Itoa(i), 5.5, 5.5, 5.5, 1))
+	}
+
+	require.Equal(t, 2, boxpacker3.VolumeLowerBound(box, big))
+	require.Equal(t, 1, boxpacker3.WeightLowerBound(box, big))
+	require.Equal(t, 8, boxpacker3.MPVLowerBound(box, big))
+	require.Equal(t, 8, boxpacker3.LowerBound(box, big))
+
+	// Flat slabs over half of width and height stack along the depth: three fit per box.
+	slabs := make([]*boxpacker3.Item, 0, 7)
+	for i := range 7 {
+		slabs = append(slabs, boxpacker3.NewItem("slab-"+strconv.Itoa(i), 6, 6, 3, 40, boxpacker3.WithoutRotation()))
+	}
+
+	require.Equal(t, 3, boxpacker3.MPVLowerBound(box, slabs))
+	require.Equal(t, 3, boxpacker3.WeightLowerBound(box, slabs))
+	require.Equal(t, 1, boxpacker3.VolumeLowerBound(box, slabs))
+
+	// A rotatable slab may lie in another plane, so it only counts when every rotation is large.
+	rotatable := []*boxpacker3.Item{boxpacker3.NewItem("slab", 6, 6, 3, 1), boxpacker3.NewItem("slab", 6, 6, 3, 1)}
+	require.Equal(t, 0, boxpacker3.MPVLowerBound(box, rotatable))
+	require.Equal(t, 1, boxpacker3.LowerBound(box, rotatable))
+
+	// Items that fit into no box are ignored.
+	unfit := []*boxpacker3.Item{boxpacker3.NewItem("huge", 11, 1, 1, 1), boxpacker3.NewItem("heavy", 1, 1, 1, 101), nil}
+	require.Equal(t, 0, boxpacker3.LowerBound(box, unfit))
+	require.Equal(t, 0, boxpacker3.LowerBound(nil, big))
+}
+
+// TestResult_OptimalityGap tests the gap between the used boxes and the lower bound.
+func TestResult_OptimalityGap(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 10, 10, 100)
+	carton := boxpacker3.NewBoxType(box, boxpacker3.UnlimitedQuantity)
+
+	items := make([]*boxpacker3.Item, 0, 4)
+	for i := range 4 {
+		items = append(items, boxpacker3.NewItem("cube-"+strconv.Itoa(i), 5.5, 5.5, 5.5, 1))
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxpacker3.BoxesFromTypes(carton), items)
+	require.NoError(t, err)
+	require.InDelta(t, 0.0, result.OptimalityGap(box), 1e-9)
+
+	// Spreading two small items over two boxes is 100% worse than the bound of one box.
+	spread := &boxpacker3.Result{Boxes: []*boxpacker3.Box{carton.NewBox(), carton.NewBox()}}
+	require.True(t, spread.Boxes[0].PutItem(boxpacker3.NewItem("a", 1, 1, 1, 1), boxpacker3.Pivot{}))
+	require.True(t, spread.Boxes[1].PutItem(boxpacker3.NewItem("b", 1, 1, 1, 1), boxpacker3.Pivot{}))
+	require.InDelta(t, 1.0, spread.OptimalityGap(box), 1e-9)
+
+	require.InDelta(t, 0.0, (&boxpacker3.Result{}).OptimalityGap(box), 1e-9)
+}
@@ -1,5 +1,7 @@
 package boxpacker3
 
+import "math"
+
 // Box represents a box that can hold items.
 type Box struct {
 	id string
@@ -11,14 +13,32 @@ type Box struct {
 	maxWeight float64
 	volume    float64
 
-	items []*Item
+	items         []*Item
+	extremePoints []Pivot
 
 	maxLength float64
 
 	itemsVolume float64
 	itemsWeight float64
+
+	boxType *BoxType
+
+	minSupport float64
+	cost       float64
+
+	cuts  []Cut
+	strip bool
+
+	axles     []Axle
+	cogLimits *[2]Pivot
+
+	pallet   bool
+	overhang float64
 }
 
+// BoxOption is a functional option for configuring a Box.
+type BoxOption func(*Box)
+
 type boxSlice []*Box
 
 func (bs boxSlice) Len() int {
@@ -42,9 +62,9 @@ func (bs boxSlice) Swap(i, j int) {
 }
 
 // NewBox creates a new Box with the given id, dimensions, and maximum weight.
-func NewBox(id string, w, h, d, mw float64) *Box {
+func NewBox(id string, w, h, d, mw float64, opts ...BoxOption) *Box {
 	//nolint:exhaustruct
-	return &Box{
+	box := &Box{
 		id:        id,
 		width:     w,
 		height:    h,
@@ -54,13 +74,54 @@ func NewBox(id string, w, h, d, mw float64) *Box {
 		volume:    w * h * d,
 		items:     make([]*Item, 0, 1),
 	}
+
+	for _, opt := range opts {
+		opt(box)
+	}
+
+	return box
 }
 
 // NewBox2D creates a new 2D Box with the given id, dimensions, and maximum weight.
 // The depth is set to 1, making it effectively 2D (width x height).
 // This is useful for packing flat items like sheets, boards, or panels.
-func NewBox2D(id string, w, h, mw float64) *Box {
-	return NewBox(id, w, h, 1, mw)
+func NewBox2D(id string, w, h, mw float64, opts ...BoxOption) *Box {
+	return NewBox(id, w, h, 1, mw, opts...)
+}
+
+// StripLength is the length of a strip created by NewStrip, i.e. the open-ended height of the box.
+const StripLength = math.MaxFloat32
+
+// NewStrip creates a 2D strip of the given width whose length (the height) is open-ended,
+// e.g. a fabric or paper roll. Items are laid out from the start of the strip, and the
+// consumed length is reported by GetUsedLength.
+func NewStrip(id string, width, maxWeight float64, opts ...BoxOption) *Box {
+	box := NewBox2D(id, width, StripLength, maxWeight, opts...)
+	box.strip = true
+
+	return box
+}
+
+// IsStrip reports whether the box is a strip created by NewStrip.
+func (b *Box) IsStrip() bool {
+	return b.strip
+}
+
+// GetUsedLength returns the length of the box taken by its items, i.e. the largest extent of an item
+// along the height. For a strip it is the consumed length of the roll, for a pallet the height of the load.
+func (b *Box) GetUsedLength() float64 {
+	var length float64
+
+	for _, it := range b.items {
+		length = max(length, it.position[HeightAxis]+it.GetDimension()[HeightAxis])
+	}
+
+	return length
+}
+
+// Is2D reports whether the box is 2D, i.e. its depth is 1 as set by NewBox2D.
+func (b *Box) Is2D() bool {
+	return b.depth == 1
 }
 
 func (b *Box) GetID() string {
@@ -87,6 +148,11 @@ func (b *Box) GetMaxWeight() float64 {
 	return b.maxWeight
 }
 
+// GetBoxType returns the type the box was instantiated from, or nil for a one-off box.
+func (b *Box) GetBoxType() *BoxType {
+	return b.boxType
+}
+
 // GetItems returns a copy of the items slice.
 func (b *Box) GetItems() []*Item {
 	return append([]*Item(nil), b.items...)
@@ -96,6 +162,31 @@ func (b *Box) GetRemainingVolume() float64 {
 	return b.volume - b.itemsVolume
 }
 
+// GetFillRate returns the ratio of the items volume to the box volume.
+// For a strip, only the used length counts towards the volume.
+func (b *Box) GetFillRate() float64 {
+	volume := b.usedVolume()
+	if volume <= 0 {
+		return 0
+	}
+
+	return b.itemsVolume / volume
+}
+
+// usedVolume returns the volume of the box, or for a strip the volume of its consumed length.
+func (b *Box) usedVolume() float64 {
+	if b.strip {
+		return b.width * b.GetUsedLength() * b.depth
+	}
+
+	return b.volume
+}
+
+// GetItemsWeight returns the total weight of the items in the box.
+func (b *Box) GetItemsWeight() float64 {
+	return b.itemsWeight
+}
+
 func (b *Box) PutItem(item *Item, p Pivot) bool {
 	if item == nil {
 		return false
@@ -108,6 +199,10 @@ func (b *Box) PutItem(item *Item, p Pivot) bool {
 	item.position = p
 
 	for rt := RotationTypeWhd; rt <= RotationTypeWdh; rt++ {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
 		matrix := rotationMatrix[rt]
 
 		//nolint:gosec // rotationMatrix values are guaranteed to be in range [0, 2] by const definition
@@ -129,6 +224,10 @@ func (b *Box) PutItem(item *Item, p Pivot) bool {
 			continue
 		}
 
+		if !b.meetsLimits(item) {
+			continue
+		}
+
 		b.insert(item)
 
 		return true
@@ -137,6 +236,24 @@ func (b *Box) PutItem(item *Item, p Pivot) bool {
 	return false
 }
 
+// meetsLimits reports whether the item, at its current position and rotation, keeps the overhang, stability,
+// load and balance limits of the box. Engines that place items without PutItem must check it as well.
+func (b *Box) meetsLimits(item *Item) bool {
+	return b.overDeck(item) && b.isSupported(item) && b.canCarry(item) && b.keepsBalance(item)
+}
+
+// meetsLimitsAt reports whether the item would keep the limits of the box at the given position and rotation.
+// The item keeps its own position and rotation.
+func (b *Box) meetsLimitsAt(item *Item, p Pivot, rt RotationType) bool {
+	position, rotation := item.position, item.rotationType
+	defer func() { item.position, item.rotationType = position, rotation }()
+
+	item.position = p
+	item.setRotationType(rt)
+
+	return b.meetsLimits(item)
+}
+
 func (b *Box) itemsIntersect(item *Item) bool {
 	if item == nil {
 		return false
@@ -187,6 +304,13 @@ func (b *Box) clone() cloner {
 		maxLength:   b.maxLength,
 		itemsVolume: b.itemsVolume,
 		itemsWeight: b.itemsWeight,
+		boxType:     b.boxType,
+		minSupport:  b.minSupport,
+		cost:        b.cost,
+		strip:       b.strip,
+		cogLimits:   b.cogLimits,
+		pallet:      b.pallet,
+		overhang:    b.overhang,
 	}
 	if b.items != nil {
 		copyBox.items = make([]*Item, len(b.items), cap(b.items))
@@ -195,17 +319,27 @@ func (b *Box) clone() cloner {
 		copyBox.items = nil
 	}
 
+	copyBox.extremePoints = append([]Pivot(nil), b.extremePoints...)
+	copyBox.cuts = append([]Cut(nil), b.cuts...)
+	copyBox.axles = append([]Axle(nil), b.axles...)
+
 	return copyBox
 }
 
 func (b *Box) insert(item *Item) {
+	item.unfitReason = UnfitReasonNone
 	b.items = append(b.items, item)
 	b.itemsVolume += item.volume
 	b.itemsWeight += item.weight
+	b.addExtremePoints(item)
 }
 
 func (b *Box) Reset() {
 	b.items = b.items[:0]
+	b.extremePoints = b.extremePoints[:0]
 	b.itemsVolume = 0
 	b.itemsWeight = 0
+	b.cuts = nil
+	b.addDeckPoint()
+	b.addBalancePoints()
 }
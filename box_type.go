@@ -0,0 +1,74 @@
+package boxpacker3
+
+// UnlimitedQuantity marks a BoxType whose supply is never exhausted.
+const UnlimitedQuantity = 0
+
+// BoxType describes a kind of box that is available in a given quantity.
+//
+// Instead of pre-cloning the same Box many times, pass one instance of the type
+// (see NewBox and BoxesFromTypes) to the packer. Built-in strategies open fresh
+// instances of the type on demand until its quantity is exhausted.
+type BoxType struct {
+	prototype *Box
+	quantity  int
+}
+
+// NewBoxType creates a box type from a prototype box.
+// The prototype is copied and emptied, so it can be safely reused by the caller.
+// A quantity of UnlimitedQuantity (or any non-positive value) means unlimited supply.
+func NewBoxType(prototype *Box, quantity int) *BoxType {
+	if prototype == nil {
+		return nil
+	}
+
+	proto := CopyPtr(prototype)
+	proto.Reset()
+	proto.boxType = nil
+
+	return &BoxType{
+		prototype: proto,
+		quantity:  max(quantity, UnlimitedQuantity),
+	}
+}
+
+// BoxesFromTypes returns one fresh box per type, ready to be passed to PackCtx.
+// Strategies open further instances of each type as needed.
+func BoxesFromTypes(types ...*BoxType) []*Box {
+	boxes := make([]*Box, 0, len(types))
+
+	for _, bt := range types {
+		if bt != nil {
+			boxes = append(boxes, bt.NewBox())
+		}
+	}
+
+	return boxes
+}
+
+// GetID returns the id of the prototype box, shared by every instance of the type.
+func (bt *BoxType) GetID() string {
+	return bt.prototype.id
+}
+
+// GetQuantity returns the number of boxes available, or UnlimitedQuantity.
+func (bt *BoxType) GetQuantity() int {
+	return bt.quantity
+}
+
+// IsUnlimited reports whether the type has an unlimited supply.
+func (bt *BoxType) IsUnlimited() bool {
+	return bt.quantity == UnlimitedQuantity
+}
+
+// NewBox creates a fresh, empty instance of the box type.
+func (bt *BoxType) NewBox() *Box {
+	b := CopyPtr(bt.prototype)
+	b.boxType = bt
+
+	return b
+}
+
+// allows reports whether another instance may be opened when n instances already exist.
+func (bt *BoxType) allows(n int) bool {
+	return bt.IsUnlimited() || n < bt.quantity
+}
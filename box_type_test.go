@@ -0,0 +1,132 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestBoxType_UnlimitedSupply verifies that every built-in strategy opens as many
+// instances of an unlimited box type as the items require.
+func TestBoxType_UnlimitedSupply(t *testing.T) {
+	t.Parallel()
+
+	strategies := []boxpacker3.PackingStrategy{
+		boxpacker3.StrategyMinimizeBoxes,
+		boxpacker3.StrategyGreedy,
+		boxpacker3.StrategyBestFit,
+		boxpacker3.StrategyBestFitDecreasing,
+		boxpacker3.StrategyNextFit,
+		boxpacker3.StrategyWorstFit,
+		boxpacker3.StrategyAlmostWorstFit,
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategyName(strategy), func(t *testing.T) {
+			t.Parallel()
+
+			carton := boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 100, 100, 100, 10000), boxpacker3.UnlimitedQuantity)
+
+			// Each carton holds exactly one item.
+			items := make([]*boxpacker3.Item, 0, 5)
+			for i := range 5 {
+				items = append(items, boxpacker3.NewItem("item-"+strconv.Itoa(i), 60, 60, 60, 10))
+			}
+
+			packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy))
+			result, err := packer.PackCtx(context.Background(), boxpacker3.BoxesFromTypes(carton), items)
+			require.NoError(t, err)
+			require.Empty(t, result.UnfitItems)
+			require.Len(t, result.Boxes, 5, "No empty spare boxes should be reported")
+
+			for _, box := range result.Boxes {
+				require.Same(t, carton, box.GetBoxType())
+				require.Equal(t, "carton", box.GetID())
+				require.Len(t, box.GetItems(), 1)
+			}
+		})
+	}
+}
+
+// TestBoxType_LimitedQuantity verifies that the quantity of a box type is never exceeded.
+func TestBoxType_LimitedQuantity(t *testing.T) {
+	t.Parallel()
+
+	carton := boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 100, 100, 100, 10000), 2)
+	require.Equal(t, 2, carton.GetQuantity())
+	require.False(t, carton.IsUnlimited())
+
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("item-1", 60, 60, 60, 10),
+		boxpacker3.NewItem("item-2", 60, 60, 60, 10),
+		boxpacker3.NewItem("item-3", 60, 60, 60, 10),
+	}
+
+	packer := boxpacker3.NewPacker()
+	result, err := packer.PackCtx(context.Background(), boxpacker3.BoxesFromTypes(carton), items)
+	require.NoError(t, err)
+	require.Len(t, result.Boxes, 2)
+	require.Len(t, result.UnfitItems, 1)
+}
+
+// TestBoxType_MixedWithPlainBoxes verifies that one-off boxes and box types can be combined
+// and that an unused seed is reported just like an unused one-off box.
+func TestBoxType_MixedWithPlainBoxes(t *testing.T) {
+	t.Parallel()
+
+	small := boxpacker3.NewBoxType(boxpacker3.NewBox("small", 50, 50, 50, 10000), 2)
+	large := boxpacker3.NewBoxType(boxpacker3.NewBox("large", 70, 70, 70, 10000), boxpacker3.UnlimitedQuantity)
+
+	// Every box holds a single item; small boxes are tried first, then the one-off box.
+	boxes := append(boxpacker3.BoxesFromTypes(small, large), boxpacker3.NewBox("one-off", 60, 60, 60, 10000))
+
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("item-1", 40, 40, 40, 10),
+		boxpacker3.NewItem("item-2", 40, 40, 40, 10),
+		boxpacker3.NewItem("item-3", 40, 40, 40, 10),
+	}
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(boxpacker3.StrategyGreedy))
+	result, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+
+	usedByType := map[string]int{}
+
+	for _, box := range result.Boxes {
+		if len(box.GetItems()) == 0 {
+			continue
+		}
+
+		if bt := box.GetBoxType(); bt != nil {
+			usedByType[bt.GetID()]++
+		} else {
+			usedByType[box.GetID()]++
+		}
+	}
+
+	require.Equal(t, map[string]int{"small": 2, "one-off": 1}, usedByType)
+	require.Len(t, result.Boxes, 4, "The unused large seed is kept, spare boxes are dropped")
+	validatePackingInvariants(t, result)
+}
+
+// TestBoxType_PrototypeIsCopied verifies that the prototype box is detached from the caller's box.
+func TestBoxType_PrototypeIsCopied(t *testing.T) {
+	t.Parallel()
+
+	proto := boxpacker3.NewBox("carton", 100, 100, 100, 10000)
+	require.True(t, proto.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 1), boxpacker3.Pivot{}))
+
+	carton := boxpacker3.NewBoxType(proto, boxpacker3.UnlimitedQuantity)
+	box := carton.NewBox()
+
+	require.Empty(t, box.GetItems())
+	require.Len(t, proto.GetItems(), 1)
+	require.Nil(t, proto.GetBoxType())
+	require.Same(t, carton, box.GetBoxType())
+	require.Nil(t, boxpacker3.NewBoxType(nil, 1))
+}
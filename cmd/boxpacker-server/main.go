@@ -0,0 +1,66 @@
+// Command boxpacker-server serves pack requests over HTTP (see package httpapi).
+//
+//	boxpacker-server -addr :8080 -max-timeout 5s
+//	curl -d @order.json localhost:8080/pack
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bavix/boxpacker3/httpapi"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	defaultTimeout := flag.Duration("timeout", 0, "packing timeout for requests without timeoutMs, 0 for none")
+	maxTimeout := flag.Duration("max-timeout", 30*time.Second, "maximum packing timeout, 0 for none") //nolint:mnd
+	maxBody := flag.Int64("max-body", httpapi.DefaultMaxBodyBytes, "maximum request body size in bytes")
+
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.Handle("/pack", httpapi.NewHandler(
+		httpapi.WithDefaultTimeout(*defaultTimeout),
+		httpapi.WithMaxTimeout(*maxTimeout),
+		httpapi.WithMaxBodyBytes(*maxBody),
+	))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second, //nolint:mnd
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
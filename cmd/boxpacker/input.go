@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bavix/boxpacker3"
+)
+
+var errMissingColumn = errors.New("missing column")
+
+// order is the combined stdin document.
+type order struct {
+	Boxes []*boxpacker3.Box  `json:"boxes"`
+	Items []*boxpacker3.Item `json:"items"`
+}
+
+func readOrder(r io.Reader) ([]*boxpacker3.Box, []*boxpacker3.Item, error) {
+	var o order
+
+	if err := json.NewDecoder(r).Decode(&o); err != nil {
+		return nil, nil, fmt.Errorf("order: %w", err)
+	}
+
+	return o.Boxes, o.Items, nil
+}
+
+func readBoxes(r io.Reader) ([]*boxpacker3.Box, error) {
+	data, isJSON, err := sniff(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if isJSON {
+		var boxes []*boxpacker3.Box
+
+		return boxes, json.Unmarshal(data, &boxes)
+	}
+
+	rows, err := readCSV(data, "id", "width", "height", "depth", "maxweight")
+	if err != nil {
+		return nil, err
+	}
+
+	boxes := make([]*boxpacker3.Box, 0, len(rows))
+
+	for _, row := range rows {
+		dims, err := row.floats("width", "height", "depth", "maxweight", "cost", "minsupport")
+		if err != nil {
+			return nil, err
+		}
+
+		box := boxpacker3.NewBox(row.get("id"), dims[0], dims[1], dims[2], dims[3],
+			boxpacker3.WithCost(dims[4]), boxpacker3.WithMinSupport(dims[5]))
+
+		if quantity := row.get("quantity"); quantity != "" {
+			n, err := strconv.Atoi(quantity)
+			if err != nil {
+				return nil, row.errorf("quantity: %w", err)
+			}
+
+			box = boxpacker3.NewBoxType(box, n).NewBox()
+		}
+
+		boxes = append(boxes, box)
+	}
+
+	return boxes, nil
+}
+
+func readItems(r io.Reader) ([]*boxpacker3.Item, error) {
+	data, isJSON, err := sniff(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if isJSON {
+		var items []*boxpacker3.Item
+
+		return items, json.Unmarshal(data, &items)
+	}
+
+	rows, err := readCSV(data, "id", "width", "height", "depth", "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*boxpacker3.Item, 0, len(rows))
+
+	for _, row := range rows {
+		dims, err := row.floats("width", "height", "depth", "weight")
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []boxpacker3.ItemOption
+
+		if maxLoad := row.get("maxload"); maxLoad != "" {
+			v, err := strconv.ParseFloat(maxLoad, 64)
+			if err != nil {
+				return nil, row.errorf("max_load: %w", err)
+			}
+
+			opts = append(opts, boxpacker3.WithMaxLoad(v))
+		}
+
+		if rotations := row.get("rotations"); rotations != "" {
+			allowed := make([]boxpacker3.RotationType, 0, len(rotations))
+
+			for _, name := range strings.Split(rotations, "|") {
+				var rt boxpacker3.RotationType
+				if err := rt.UnmarshalText([]byte(strings.ToUpper(strings.TrimSpace(name)))); err != nil {
+					return nil, row.errorf("rotations: %w", err)
+				}
+
+				allowed = append(allowed, rt)
+			}
+
+			opts = append(opts, boxpacker3.WithAllowedRotations(allowed...))
+		}
+
+		item := boxpacker3.NewItem(row.get("id"), dims[0], dims[1], dims[2], dims[3], opts...)
+
+		if quantity := row.get("quantity"); quantity != "" {
+			n, err := strconv.Atoi(quantity)
+			if err != nil {
+				return nil, row.errorf("quantity: %w", err)
+			}
+
+			items = append(items, boxpacker3.NewItemLine(item, n).Units()...)
+
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// sniff reads the whole input and reports whether it looks like JSON.
+func sniff(r io.Reader) ([]byte, bool, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	trimmed := bytes.TrimSpace(data)
+
+	return data, len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{'), nil
+}
+
+// csvRow is a CSV record addressed by normalized column names.
+type csvRow struct {
+	line    int
+	columns map[string]int
+	record  []string
+}
+
+// readCSV parses CSV with a header row. Column names are matched case-insensitively,
+// ignoring underscores, dashes and spaces, so "max_weight" and "MaxWeight" are the same column.
+func readCSV(data []byte, required ...string) ([]csvRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[normalizeColumn(name)] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", errMissingColumn, name)
+		}
+	}
+
+	rows := make([]csvRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		rows = append(rows, csvRow{line: i + 2, columns: columns, record: record}) //nolint:mnd // header is line 1
+	}
+
+	return rows, nil
+}
+
+func normalizeColumn(name string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func (r csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.record[i])
+}
+
+// floats parses the given columns; empty optional columns are 0.
+func (r csvRow) floats(columns ...string) ([]float64, error) {
+	values := make([]float64, len(columns))
+
+	for i, column := range columns {
+		raw := r.get(column)
+		if raw == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, r.errorf("%s: %w", column, err)
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func (r csvRow) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: "+format, append([]any{r.line}, args...)...)
+}
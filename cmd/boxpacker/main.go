@@ -0,0 +1,211 @@
+// Command boxpacker packs items into boxes from the command line.
+//
+// Boxes and items are read from JSON or CSV files (the format is detected from the content),
+// or as a single JSON document {"boxes": [...], "items": [...]} from stdin:
+//
+//	boxpacker -boxes boxes.csv -items items.json
+//	boxpacker -strategy all -goal TightestPacking -format json < order.json
+//
+// Box CSV columns: id, width, height, depth, max_weight and optionally cost, min_support and quantity
+// (an empty quantity means a one-off box, 0 an unlimited box type).
+// Item CSV columns: id, width, height, depth, weight and optionally max_load, rotations (e.g. "WHD|DHW")
+// and quantity (the row is an order line of that many units).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bavix/boxpacker3"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+var errStdinTwice = errors.New("only one of -boxes and -items can be read from stdin")
+
+type config struct {
+	boxesPath  string
+	itemsPath  string
+	strategies string
+	goal       string
+	format     string
+	timeout    time.Duration
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var cfg config
+
+	fs := flag.NewFlagSet("boxpacker", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&cfg.boxesPath, "boxes", "", "boxes file (JSON or CSV), - for stdin")
+	fs.StringVar(&cfg.itemsPath, "items", "", "items file (JSON or CSV), - for stdin")
+	fs.StringVar(&cfg.strategies, "strategy", boxpacker3.StrategyMinimizeBoxes.String(),
+		"comma-separated strategies, or \"all\"; several strategies run in parallel")
+	fs.StringVar(&cfg.goal, "goal", "MinimizeBoxes",
+		"goal used to pick the best parallel result: "+strings.Join(boxpacker3.GoalNames(), ", "))
+	fs.StringVar(&cfg.format, "format", "table", "output format: table or json")
+	fs.DurationVar(&cfg.timeout, "timeout", 0, "packing timeout, 0 for none")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	strategy, err := packerOption(cfg.strategies, cfg.goal)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	write, err := newWriter(cfg.format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	boxes, items, err := readInput(cfg, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	ctx := context.Background()
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	result, err := boxpacker3.NewPacker(
+		strategy,
+		boxpacker3.WithInputValidation(),
+	).PackCtx(ctx, boxes, items)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	if err := write(stdout, result); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	return exitOK
+}
+
+// packerOption selects a single strategy, or a ParallelStrategy when several strategies are requested.
+// A single strategy is set with WithStrategy, so 2D inputs are packed by the same engines as by the library.
+func packerOption(strategies, goalName string) (boxpacker3.PackerOption, error) {
+	var selected []boxpacker3.PackingStrategy
+
+	if strings.EqualFold(strategies, "all") {
+		selected = boxpacker3.PackingStrategies()
+	} else {
+		for _, name := range strings.Split(strategies, ",") {
+			s, err := boxpacker3.ParsePackingStrategy(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+
+			selected = append(selected, s)
+		}
+	}
+
+	goal, err := boxpacker3.ParseGoal(goalName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) == 1 {
+		return boxpacker3.WithStrategy(selected[0]), nil
+	}
+
+	algorithms := make([]boxpacker3.PackingAlgorithm, 0, len(selected))
+	for _, s := range selected {
+		algorithms = append(algorithms, boxpacker3.NewAlgorithm(s))
+	}
+
+	return boxpacker3.WithAlgorithm(boxpacker3.NewParallelStrategy(
+		boxpacker3.WithAlgorithms(algorithms...),
+		boxpacker3.WithGoal(goal),
+	)), nil
+}
+
+// readInput reads boxes and items from the configured files, or a combined document from stdin.
+func readInput(cfg config, stdin io.Reader) ([]*boxpacker3.Box, []*boxpacker3.Item, error) {
+	if cfg.boxesPath == "" && cfg.itemsPath == "" {
+		return readOrder(stdin)
+	}
+
+	if cfg.boxesPath == "-" && cfg.itemsPath == "-" {
+		return nil, nil, errStdinTwice
+	}
+
+	var (
+		boxes []*boxpacker3.Box
+		items []*boxpacker3.Item
+	)
+
+	err := withInput(cfg.boxesPath, stdin, func(r io.Reader) error {
+		var err error
+
+		boxes, err = readBoxes(r)
+
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("boxes: %w", err)
+	}
+
+	err = withInput(cfg.itemsPath, stdin, func(r io.Reader) error {
+		var err error
+
+		items, err = readItems(r)
+
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("items: %w", err)
+	}
+
+	return boxes, items, nil
+}
+
+// withInput calls fn with stdin for "-" or with the opened file otherwise.
+func withInput(path string, stdin io.Reader, fn func(r io.Reader) error) error {
+	if path == "" {
+		return fn(strings.NewReader("[]"))
+	}
+
+	if path == "-" {
+		return fn(stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return fn(f)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestRun_CSVFiles tests packing boxes and items read from CSV files into a table.
+func TestRun_CSVFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	boxes := filepath.Join(dir, "boxes.csv")
+	items := filepath.Join(dir, "items.csv")
+
+	require.NoError(t, os.WriteFile(boxes, []byte("id,width,height,depth,max_weight,quantity\ncarton,100,100,100,1000,0\n"), 0o600))
+	require.NoError(t, os.WriteFile(items, []byte("ID,Width,Height,Depth,Weight,Rotations,Quantity\n"+
+		"tv,60,80,60,10,WHD|DHW,\nmug,60,60,60,1,,2\nbig,200,10,10,1,,\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-boxes", boxes, "-items", items, "-strategy", "Greedy"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	out := stdout.String()
+	require.Contains(t, out, "carton")
+	require.Contains(t, out, "tv")
+	require.Contains(t, out, "UNFIT")
+	require.Contains(t, out, "big")
+	require.Contains(t, out, "TooLarge")
+	require.Equal(t, 3, strings.Count(out, "carton"), "Each item needs its own carton")
+	require.Equal(t, 2, strings.Count(out, "mug"))
+}
+
+// TestRun_StdinJSON tests packing a combined JSON document from stdin with several strategies.
+func TestRun_StdinJSON(t *testing.T) {
+	t.Parallel()
+
+	input := `{
+		"boxes": [{"id": "small", "width": 50, "height": 50, "depth": 50, "maxWeight": 100}],
+		"items": [{"id": "a", "width": 20, "height": 20, "depth": 20, "weight": 1}]
+	}`
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-strategy", "all", "-goal", "tightestpacking", "-format", "json", "-timeout", "10s"},
+		strings.NewReader(input), &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	var result boxpacker3.Result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	require.Len(t, result.Boxes, 1)
+	require.Len(t, result.Boxes[0].GetItems(), 1)
+	require.Empty(t, result.UnfitItems)
+}
+
+// TestRun_Errors tests the exit codes for invalid flags and input.
+func TestRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		args []string
+		in   string
+		code int
+	}{
+		{"unknown flag", []string{"-nope"}, "", exitUsage},
+		{"unknown strategy", []string{"-strategy", "Magic"}, "", exitUsage},
+		{"unknown goal", []string{"-goal", "Magic"}, "", exitUsage},
+		{"unknown format", []string{"-format", "xml"}, "", exitUsage},
+		{"both from stdin", []string{"-boxes", "-", "-items", "-"}, "", exitError},
+		{"missing column", []string{"-boxes", "-"}, "id,width\nb,1\n", exitError},
+		{"bad number", []string{"-items", "-"}, "id,width,height,depth,weight\ni,x,1,1,1\n", exitError},
+		{"bad json", nil, "{", exitError},
+		{"invalid item", []string{"-items", "-"}, "id,width,height,depth,weight\ni,0,1,1,1\n", exitError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			require.Equal(t, c.code, run(c.args, strings.NewReader(c.in), &stdout, &stderr))
+			require.NotEmpty(t, stderr.String())
+		})
+	}
+}
+
+// TestRun_Sheets tests that a single strategy packs 2D input with the 2D engine, as the library does.
+func TestRun_Sheets(t *testing.T) {
+	t.Parallel()
+
+	input := `{
+		"boxes": [{"id": "sheet", "width": 100, "height": 100, "depth": 1, "maxWeight": 1000}],
+		"items": [
+			{"id": "a", "width": 60, "height": 30, "depth": 1, "weight": 1},
+			{"id": "b", "width": 70, "height": 40, "depth": 1, "weight": 1},
+			{"id": "c", "width": 30, "height": 50, "depth": 1, "weight": 1},
+			{"id": "d", "width": 40, "height": 60, "depth": 1, "weight": 1}
+		]
+	}`
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-strategy", "MinimizeBoxes", "-format", "json"}, strings.NewReader(input), &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	expected, err := boxpacker3.NewPacker().PackCtx(context.Background(),
+		[]*boxpacker3.Box{boxpacker3.NewBox2D("sheet", 100, 100, 1000)},
+		[]*boxpacker3.Item{
+			boxpacker3.NewItem2D("a", 60, 30, 1),
+			boxpacker3.NewItem2D("b", 70, 40, 1),
+			boxpacker3.NewItem2D("c", 30, 50, 1),
+			boxpacker3.NewItem2D("d", 40, 60, 1),
+		})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), stdout.String())
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bavix/boxpacker3"
+)
+
+var errUnknownFormat = errors.New("unknown output format")
+
+type writer func(w io.Writer, result *boxpacker3.Result) error
+
+func newWriter(format string) (writer, error) {
+	switch strings.ToLower(format) {
+	case "table":
+		return writeTable, nil
+	case "json":
+		return writeJSON, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+}
+
+func writeJSON(w io.Writer, result *boxpacker3.Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(result)
+}
+
+// writeTable prints every used box followed by its items, then the unfit items.
+func writeTable(w io.Writer, result *boxpacker3.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(tw, "BOX\tITEM\tPOSITION\tROTATION\tDIMENSION\tWEIGHT\tFILL")
+
+	for _, box := range result.Boxes {
+		if box == nil || len(box.GetItems()) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t\t\t\t%s\t%g\t%.2f%%\n",
+			box.GetID(), formatTriple(box.GetWidth(), box.GetHeight(), box.GetDepth(), "x"),
+			box.GetItemsWeight(), box.GetFillRate()*100) //nolint:mnd
+
+		for _, item := range box.GetItems() {
+			pos := item.GetPosition()
+			dim := item.GetDimension()
+
+			fmt.Fprintf(tw, "\t%s\t%s\t%s\t%s\t%g\t\n",
+				item.GetID(), formatTriple(pos[0], pos[1], pos[2], ","), item.GetRotationType(),
+				formatTriple(dim[0], dim[1], dim[2], "x"), item.GetWeight())
+		}
+	}
+
+	if len(result.UnfitItems) > 0 {
+		fmt.Fprintln(tw, "UNFIT\t\tREASON\t\t\t\t")
+
+		for _, item := range result.UnfitItems {
+			if item == nil {
+				continue
+			}
+
+			fmt.Fprintf(tw, "\t%s\t%s\t\t%s\t%g\t\n",
+				item.GetID(), item.GetUnfitReason(), formatTriple(item.GetWidth(), item.GetHeight(), item.GetDepth(), "x"),
+				item.GetWeight())
+		}
+	}
+
+	return tw.Flush()
+}
+
+func formatTriple(a, b, c float64, sep string) string {
+	return fmt.Sprintf("%g%s%g%s%g", a, sep, b, sep, c)
+}
@@ -1,5 +1,14 @@
 package boxpacker3
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownStrategy is returned when parsing an unknown packing strategy name.
+var ErrUnknownStrategy = errors.New("boxpacker3: unknown packing strategy")
+
 type RotationType int
 
 const (
@@ -11,6 +20,26 @@ const (
 	RotationTypeWdh
 )
 
+// String returns the axis order of the rotation, e.g. "WHD" for the original orientation.
+func (rt RotationType) String() string {
+	switch rt {
+	case RotationTypeWhd:
+		return "WHD"
+	case RotationTypeHwd:
+		return "HWD"
+	case RotationTypeHdw:
+		return "HDW"
+	case RotationTypeDhw:
+		return "DHW"
+	case RotationTypeDwh:
+		return "DWH"
+	case RotationTypeWdh:
+		return "WDH"
+	default:
+		return "Unknown"
+	}
+}
+
 // rotationMatrix defines rotation matrices for all 6 possible 3D rotations of an item.
 //
 // Each item stores its dimensions as whd [3]float64 = [width, height, depth] = [0, 1, 2].
@@ -104,3 +133,49 @@ const (
 	// This prevents items from being placed in boxes that are nearly empty.
 	StrategyAlmostWorstFit
 )
+
+// PackingStrategies returns all built-in packing strategies.
+func PackingStrategies() []PackingStrategy {
+	return []PackingStrategy{
+		StrategyMinimizeBoxes,
+		StrategyGreedy,
+		StrategyBestFit,
+		StrategyBestFitDecreasing,
+		StrategyNextFit,
+		StrategyWorstFit,
+		StrategyAlmostWorstFit,
+	}
+}
+
+// String returns the name of the strategy, the same as the Name of its PackingAlgorithm.
+func (s PackingStrategy) String() string {
+	switch s {
+	case StrategyMinimizeBoxes:
+		return "MinimizeBoxes"
+	case StrategyGreedy:
+		return "Greedy"
+	case StrategyBestFit:
+		return "BestFit"
+	case StrategyBestFitDecreasing:
+		return "BestFitDecreasing"
+	case StrategyNextFit:
+		return "NextFit"
+	case StrategyWorstFit:
+		return "WorstFit"
+	case StrategyAlmostWorstFit:
+		return "AlmostWorstFit"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// ParsePackingStrategy returns the strategy with the given name (case-insensitive), e.g. "BestFitDecreasing".
+func ParsePackingStrategy(name string) (PackingStrategy, error) {
+	for _, s := range PackingStrategies() {
+		if strings.EqualFold(s.String(), name) {
+			return s, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+}
@@ -0,0 +1,45 @@
+package boxpacker3
+
+// CostFunc calculates the shipping cost of a whole packing result.
+// It can be used to add carrier charges on top of the price of the boxes.
+type CostFunc func(res *Result) float64
+
+// WithCost sets the price of the box.
+func WithCost(cost float64) BoxOption {
+	return func(b *Box) {
+		b.cost = cost
+	}
+}
+
+// GetCost returns the price of the box.
+func (b *Box) GetCost() float64 {
+	return b.cost
+}
+
+// TotalBoxCost is the default CostFunc: the sum of the prices of all used boxes.
+func TotalBoxCost(res *Result) float64 {
+	return PerBoxCost(func(b *Box) float64 {
+		return b.cost
+	})(res)
+}
+
+// PerBoxCost builds a CostFunc that sums the given per-box cost over all used boxes.
+//
+// Usage:
+//
+//	cost := PerBoxCost(func(b *Box) float64 {
+//	    return b.GetCost() + 0.002*b.GetItemsWeight() // box price + carrier charge per gram
+//	})
+func PerBoxCost(boxCost func(b *Box) float64) CostFunc {
+	return func(res *Result) float64 {
+		var total float64
+
+		for _, b := range res.Boxes {
+			if b != nil && len(b.items) > 0 {
+				total += boxCost(b)
+			}
+		}
+
+		return total
+	}
+}
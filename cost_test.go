@@ -0,0 +1,90 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestTotalBoxCost tests that only used boxes are charged.
+func TestTotalBoxCost(t *testing.T) {
+	t.Parallel()
+
+	used := boxpacker3.NewBox("used", 10, 10, 10, 100, boxpacker3.WithCost(2.5))
+	require.True(t, used.PutItem(boxpacker3.NewItem("item", 1, 1, 1, 40), boxpacker3.Pivot{}))
+
+	res := &boxpacker3.Result{
+		Boxes: []*boxpacker3.Box{
+			used,
+			boxpacker3.NewBox("empty", 10, 10, 10, 100, boxpacker3.WithCost(100)),
+		},
+	}
+
+	require.InDelta(t, 2.5, used.GetCost(), 0.0001)
+	require.InDelta(t, 40.0, used.GetItemsWeight(), 0.0001)
+	require.InDelta(t, 2.5, boxpacker3.TotalBoxCost(res), 0.0001)
+
+	withCarrier := boxpacker3.PerBoxCost(func(b *boxpacker3.Box) float64 {
+		return b.GetCost() + 0.1*b.GetItemsWeight()
+	})
+	require.InDelta(t, 6.5, withCarrier(res), 0.0001)
+}
+
+// TestMinimizeCostGoal tests that a cheaper result wins even with more boxes.
+func TestMinimizeCostGoal(t *testing.T) {
+	t.Parallel()
+
+	expensive := boxpacker3.NewBox("expensive", 10, 10, 10, 100, boxpacker3.WithCost(10))
+	require.True(t, expensive.PutItem(boxpacker3.NewItem("item", 1, 1, 1, 1), boxpacker3.Pivot{}))
+
+	cheap1 := boxpacker3.NewBox("cheap", 10, 10, 10, 100, boxpacker3.WithCost(3))
+	cheap2 := boxpacker3.NewBox("cheap", 10, 10, 10, 100, boxpacker3.WithCost(3))
+	require.True(t, cheap1.PutItem(boxpacker3.NewItem("item", 1, 1, 1, 1), boxpacker3.Pivot{}))
+	require.True(t, cheap2.PutItem(boxpacker3.NewItem("item", 1, 1, 1, 1), boxpacker3.Pivot{}))
+
+	resOne := &boxpacker3.Result{Boxes: []*boxpacker3.Box{expensive}}
+	resTwo := &boxpacker3.Result{Boxes: []*boxpacker3.Box{cheap1, cheap2}}
+
+	require.True(t, boxpacker3.MinimizeCostGoal(resTwo, resOne), "6 is cheaper than 10")
+	require.False(t, boxpacker3.MinimizeCostGoal(resOne, resTwo))
+	require.True(t, boxpacker3.MinimizeBoxesGoal(resOne, resTwo), "MinimizeBoxes still prefers a single box")
+
+	// A per-box carrier surcharge makes two parcels more expensive than one.
+	surcharged := boxpacker3.NewMinimizeCostGoal(boxpacker3.PerBoxCost(func(b *boxpacker3.Box) float64 {
+		return b.GetCost() + 5
+	}))
+	require.True(t, surcharged(resOne, resTwo), "15 is cheaper than 16")
+}
+
+// TestMinimizeCostGoal_Parallel tests MinimizeCostGoal as the goal of a ParallelStrategy.
+func TestMinimizeCostGoal_Parallel(t *testing.T) {
+	t.Parallel()
+
+	// The large box is cheaper per item than two small ones.
+	boxes := []*boxpacker3.Box{
+		boxpacker3.NewBox("small-1", 50, 50, 50, 1000, boxpacker3.WithCost(4)),
+		boxpacker3.NewBox("small-2", 50, 50, 50, 1000, boxpacker3.WithCost(4)),
+		boxpacker3.NewBox("large", 100, 100, 100, 1000, boxpacker3.WithCost(5)),
+	}
+
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("item-1", 40, 40, 40, 1),
+		boxpacker3.NewItem("item-2", 40, 40, 40, 1),
+	}
+
+	parallel := boxpacker3.NewParallelStrategy(
+		boxpacker3.WithAlgorithms(
+			boxpacker3.NewGreedyStrategy(),
+			boxpacker3.NewWorstFitStrategy(),
+		),
+		boxpacker3.WithGoal(boxpacker3.MinimizeCostGoal),
+	)
+
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(parallel)).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.InDelta(t, 5.0, boxpacker3.TotalBoxCost(result), 0.0001)
+}
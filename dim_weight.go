@@ -0,0 +1,104 @@
+package boxpacker3
+
+import "math"
+
+// roundingTolerance keeps values like 1.1/0.1 = 11.000000000000002 from being rounded up a full step.
+const roundingTolerance = 1e-9
+
+// RoundingFunc rounds a value according to carrier rules.
+type RoundingFunc func(value float64) float64
+
+// RoundUpTo returns a RoundingFunc that rounds up to the next multiple of step,
+// e.g. RoundUpTo(0.5) for half-kilogram increments. A non-positive step disables rounding.
+func RoundUpTo(step float64) RoundingFunc {
+	return func(value float64) float64 {
+		if step <= 0 {
+			return value
+		}
+
+		return math.Ceil(value/step-roundingTolerance) * step
+	}
+}
+
+// DimWeightCalculator calculates the billable weight of parcels the way carriers do:
+// max(actual weight, width*height*depth / divisor).
+//
+// The divisor converts box volume into weight units. For example, with dimensions in
+// centimetres and weights in kilograms a divisor of 5000 is common; with millimetres
+// and grams the same divisor of 5000 yields grams.
+type DimWeightCalculator struct {
+	divisor           float64
+	dimensionRounding RoundingFunc
+	weightRounding    RoundingFunc
+}
+
+// DimWeightOption is a functional option for configuring a DimWeightCalculator.
+type DimWeightOption func(*DimWeightCalculator)
+
+// WithDimensionRounding rounds every box dimension before the volume is calculated,
+// e.g. RoundUpTo(1) for carriers that bill whole centimetres or inches.
+func WithDimensionRounding(fn RoundingFunc) DimWeightOption {
+	return func(c *DimWeightCalculator) {
+		c.dimensionRounding = fn
+	}
+}
+
+// WithWeightRounding rounds the actual, dimensional and billable weights,
+// e.g. RoundUpTo(0.5) for carriers that bill in half-kilogram increments.
+func WithWeightRounding(fn RoundingFunc) DimWeightOption {
+	return func(c *DimWeightCalculator) {
+		c.weightRounding = fn
+	}
+}
+
+// NewDimWeightCalculator creates a calculator with the given volumetric divisor.
+// Without rounding options values are used as-is.
+func NewDimWeightCalculator(divisor float64, opts ...DimWeightOption) *DimWeightCalculator {
+	c := &DimWeightCalculator{
+		divisor:           divisor,
+		dimensionRounding: noRounding,
+		weightRounding:    noRounding,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// GetDivisor returns the volumetric divisor.
+func (c *DimWeightCalculator) GetDivisor() float64 {
+	return c.divisor
+}
+
+// ActualWeight returns the rounded weight of the items in the box.
+func (c *DimWeightCalculator) ActualWeight(b *Box) float64 {
+	return c.weightRounding(b.itemsWeight)
+}
+
+// DimensionalWeight returns the rounded volumetric weight of the box.
+func (c *DimWeightCalculator) DimensionalWeight(b *Box) float64 {
+	if c.divisor <= 0 {
+		return 0
+	}
+
+	volume := c.dimensionRounding(b.width) * c.dimensionRounding(b.height) * c.dimensionRounding(b.depth)
+
+	return c.weightRounding(volume / c.divisor)
+}
+
+// BillableWeight returns the weight the carrier charges for the box.
+func (c *DimWeightCalculator) BillableWeight(b *Box) float64 {
+	return max(c.ActualWeight(b), c.DimensionalWeight(b))
+}
+
+// TotalBillableWeight returns the billable weight of all used boxes in the result.
+// It can be used as a CostFunc.
+func (c *DimWeightCalculator) TotalBillableWeight(res *Result) float64 {
+	return PerBoxCost(c.BillableWeight)(res)
+}
+
+func noRounding(value float64) float64 {
+	return value
+}
@@ -0,0 +1,77 @@
+package boxpacker3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestRoundUpTo tests the carrier rounding helper.
+func TestRoundUpTo(t *testing.T) {
+	t.Parallel()
+
+	half := boxpacker3.RoundUpTo(0.5)
+	require.InDelta(t, 1.0, half(1.0), 0.0001)
+	require.InDelta(t, 1.5, half(1.01), 0.0001)
+	require.InDelta(t, 1.1, boxpacker3.RoundUpTo(0.1)(1.1), 0.0001, "Floating point noise must not add a step")
+	require.InDelta(t, 1.23, boxpacker3.RoundUpTo(0)(1.23), 0.0001)
+}
+
+// TestDimWeightCalculator tests dimensional and billable weight of a box.
+func TestDimWeightCalculator(t *testing.T) {
+	t.Parallel()
+
+	// 40x30x20 cm box with 2.2 kg of items.
+	box := boxpacker3.NewBox("box", 40, 30, 20, 30)
+	require.True(t, box.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 2.2), boxpacker3.Pivot{}))
+
+	plain := boxpacker3.NewDimWeightCalculator(5000)
+	require.InDelta(t, 5000.0, plain.GetDivisor(), 0.0001)
+	require.InDelta(t, 2.2, plain.ActualWeight(box), 0.0001)
+	require.InDelta(t, 4.8, plain.DimensionalWeight(box), 0.0001)
+	require.InDelta(t, 4.8, plain.BillableWeight(box), 0.0001)
+
+	carrier := boxpacker3.NewDimWeightCalculator(6000,
+		boxpacker3.WithWeightRounding(boxpacker3.RoundUpTo(1)),
+	)
+	require.InDelta(t, 3.0, carrier.ActualWeight(box), 0.0001)
+	require.InDelta(t, 4.0, carrier.DimensionalWeight(box), 0.0001)
+	require.InDelta(t, 4.0, carrier.BillableWeight(box), 0.0001)
+
+	// 40.2 cm is billed as 41 cm.
+	odd := boxpacker3.NewBox("odd", 40.2, 30, 20, 30)
+	byDimension := boxpacker3.NewDimWeightCalculator(5000,
+		boxpacker3.WithDimensionRounding(boxpacker3.RoundUpTo(1)),
+	)
+	require.InDelta(t, 41*30*20/5000.0, byDimension.DimensionalWeight(odd), 0.0001)
+}
+
+// TestMinimizeBillableWeightGoal tests that the goal prefers the lower billable weight.
+func TestMinimizeBillableWeightGoal(t *testing.T) {
+	t.Parallel()
+
+	calc := boxpacker3.NewDimWeightCalculator(5000)
+
+	// One bulky box: 60x50x40 -> 24 kg dimensional weight.
+	bulky := boxpacker3.NewBox("bulky", 60, 50, 40, 30)
+	require.True(t, bulky.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 5), boxpacker3.Pivot{}))
+
+	// Two compact boxes: 30x20x20 -> 2.4 kg dimensional weight, 2.5 kg actual each.
+	compact1 := boxpacker3.NewBox("compact", 30, 20, 20, 30)
+	compact2 := boxpacker3.NewBox("compact", 30, 20, 20, 30)
+	require.True(t, compact1.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 2.5), boxpacker3.Pivot{}))
+	require.True(t, compact2.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 2.5), boxpacker3.Pivot{}))
+
+	resBulky := &boxpacker3.Result{Boxes: []*boxpacker3.Box{bulky}}
+	resCompact := &boxpacker3.Result{Boxes: []*boxpacker3.Box{compact1, compact2}}
+
+	require.InDelta(t, 24.0, calc.TotalBillableWeight(resBulky), 0.0001)
+	require.InDelta(t, 5.0, calc.TotalBillableWeight(resCompact), 0.0001)
+
+	goal := boxpacker3.NewMinimizeBillableWeightGoal(calc)
+	require.True(t, goal(resCompact, resBulky))
+	require.False(t, goal(resBulky, resCompact))
+	require.True(t, goal(resBulky, nil))
+}
@@ -0,0 +1,154 @@
+package boxpacker3
+
+import (
+	"math"
+	"slices"
+)
+
+// pointTolerance is used to deduplicate extreme points and to test whether a point is covered by an item.
+const pointTolerance = 1e-9
+
+// GetExtremePoints returns the candidate pivots for the next item, in the order strategies try them.
+//
+// The box maintains a set of extreme points: the corners of placed items along each axis
+// plus their projections onto the box walls and onto the faces of other items. Unlike the
+// three corner offsets per item, projections also reach free space hidden behind or below
+// neighbours, which yields tighter packings. The points are ordered bottom first, then by depth
+// and by width, so boxes are filled floor by floor from the back left corner.
+func (b *Box) GetExtremePoints() []Pivot {
+	if len(b.items) == 0 {
+		return append([]Pivot{{}}, b.extremePoints...)
+	}
+
+	return append([]Pivot(nil), b.extremePoints...)
+}
+
+// addExtremePoints updates the extreme points after the item has been inserted.
+func (b *Box) addExtremePoints(item *Item) {
+	pos := item.position
+	dim := item.GetDimension()
+
+	kept := b.extremePoints[:0]
+
+	for _, p := range b.extremePoints {
+		if !item.covers(p) {
+			kept = append(kept, p)
+		}
+	}
+
+	b.extremePoints = kept
+
+	var corners [3]Pivot
+
+	for axis := WidthAxis; axis <= DepthAxis; axis++ {
+		corners[axis] = pos
+		corners[axis][axis] += dim[axis]
+
+		b.addExtremePoint(corners[axis])
+	}
+
+	for axis := WidthAxis; axis <= DepthAxis; axis++ {
+		for dir := WidthAxis; dir <= DepthAxis; dir++ {
+			if dir != axis {
+				b.addExtremePoint(b.project(corners[axis], dir))
+			}
+		}
+	}
+}
+
+// addExtremePoint inserts the point in order (see pointLess) unless it lies outside the box,
+// is already known, or is occupied by an item.
+func (b *Box) addExtremePoint(p Pivot) {
+	if p[WidthAxis] >= b.width || p[HeightAxis] >= b.height || p[DepthAxis] >= b.depth {
+		return
+	}
+
+	for _, ep := range b.extremePoints {
+		if math.Abs(ep[WidthAxis]-p[WidthAxis]) < pointTolerance &&
+			math.Abs(ep[HeightAxis]-p[HeightAxis]) < pointTolerance &&
+			math.Abs(ep[DepthAxis]-p[DepthAxis]) < pointTolerance {
+			return
+		}
+	}
+
+	for _, it := range b.items {
+		if it != nil && it.covers(p) {
+			return
+		}
+	}
+
+	k := len(b.extremePoints)
+	for k > 0 && pointLess(p, b.extremePoints[k-1]) {
+		k--
+	}
+
+	b.extremePoints = slices.Insert(b.extremePoints, k, p)
+}
+
+// pointLess orders extreme points by height, then depth, then width.
+func pointLess(a, b Pivot) bool {
+	for _, axis := range [3]Axis{HeightAxis, DepthAxis, WidthAxis} {
+		if a[axis] != b[axis] {
+			return a[axis] < b[axis]
+		}
+	}
+
+	return false
+}
+
+// project moves the point towards the origin along the given axis until it hits
+// the box wall or the far face of an item.
+func (b *Box) project(p Pivot, axis Axis) Pivot {
+	limit := 0.0
+
+	for _, it := range b.items {
+		if it == nil {
+			continue
+		}
+
+		dim := it.GetDimension()
+		face := it.position[axis] + dim[axis]
+
+		if face > p[axis]+pointTolerance || face <= limit {
+			continue
+		}
+
+		if it.spans(p, axis) {
+			limit = face
+		}
+	}
+
+	p[axis] = limit
+
+	return p
+}
+
+// covers reports whether the point lies inside the item (the far faces are excluded).
+func (i *Item) covers(p Pivot) bool {
+	dim := i.GetDimension()
+
+	for axis := WidthAxis; axis <= DepthAxis; axis++ {
+		if p[axis] < i.position[axis]-pointTolerance || p[axis] >= i.position[axis]+dim[axis]-pointTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// spans reports whether the point lies within the item's extent on both axes other than skip.
+func (i *Item) spans(p Pivot, skip Axis) bool {
+	dim := i.GetDimension()
+
+	for axis := WidthAxis; axis <= DepthAxis; axis++ {
+		if axis == skip {
+			continue
+		}
+
+		if p[axis] < i.position[axis]-pointTolerance || p[axis] >= i.position[axis]+dim[axis]-pointTolerance {
+			return false
+		}
+	}
+
+	return true
+}
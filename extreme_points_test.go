@@ -0,0 +1,96 @@
+package boxpacker3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestBox_GetExtremePoints_Empty tests that an empty box offers only the origin.
+func TestBox_GetExtremePoints_Empty(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 100, 100, 100, 1000)
+	require.Equal(t, []boxpacker3.Pivot{{}}, box.GetExtremePoints())
+}
+
+// TestBox_GetExtremePoints_Projections tests that corners are projected onto walls and item faces.
+func TestBox_GetExtremePoints_Projections(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 100, 100, 100, 1000)
+
+	slab := boxpacker3.NewItem("slab", 50, 10, 50, 1, boxpacker3.WithoutRotation())
+	require.True(t, box.PutItem(slab, boxpacker3.Pivot{}))
+	require.Equal(t, []boxpacker3.Pivot{{50, 0, 0}, {0, 0, 50}, {0, 10, 0}}, box.GetExtremePoints())
+
+	pillar := boxpacker3.NewItem("pillar", 10, 30, 10, 1, boxpacker3.WithoutRotation())
+	require.True(t, box.PutItem(pillar, boxpacker3.Pivot{50, 0, 0}))
+
+	// {50, 0, 0} is now occupied; the top corner of the pillar is projected past the slab onto the wall.
+	// The points are ordered bottom first, then by depth, then by width.
+	require.Equal(t, []boxpacker3.Pivot{
+		{60, 0, 0},
+		{50, 0, 10},
+		{0, 0, 50},
+		{0, 10, 0},
+		{0, 30, 0},
+		{50, 30, 0},
+	}, box.GetExtremePoints())
+}
+
+// TestBox_GetExtremePoints_ResetAndCopy tests that extreme points follow Reset and CopyPtr.
+func TestBox_GetExtremePoints_ResetAndCopy(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 100, 100, 100, 1000)
+	require.True(t, box.PutItem(boxpacker3.NewItem("item", 10, 10, 10, 1), boxpacker3.Pivot{}))
+
+	clone := boxpacker3.CopyPtr(box)
+	require.Equal(t, box.GetExtremePoints(), clone.GetExtremePoints())
+
+	require.True(t, clone.PutItem(boxpacker3.NewItem("other", 10, 10, 10, 1), boxpacker3.Pivot{10, 0, 0}))
+	require.NotEqual(t, box.GetExtremePoints(), clone.GetExtremePoints(), "Clones must not share extreme points")
+
+	box.Reset()
+	require.Equal(t, []boxpacker3.Pivot{{}}, box.GetExtremePoints())
+}
+
+// TestExtremePoints_FillRate tests that extreme points pack the seeded datasets at least as well
+// as the three corner pivots per item they replaced, measured with packSeeded before the change.
+func TestExtremePoints_FillRate(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("packs 30 datasets with every strategy")
+	}
+
+	cornerPivots := map[boxpacker3.PackingStrategy]struct {
+		boxes int
+		fill  float64
+	}{
+		boxpacker3.StrategyMinimizeBoxes:     {39, 26.5689},
+		boxpacker3.StrategyGreedy:            {44, 26.1843},
+		boxpacker3.StrategyBestFit:           {211, 40.2099},
+		boxpacker3.StrategyBestFitDecreasing: {180, 67.3526},
+		boxpacker3.StrategyNextFit:           {45, 25.7308},
+		boxpacker3.StrategyWorstFit:          {30, 0.2280},
+		boxpacker3.StrategyAlmostWorstFit:    {30, 0.2280},
+	}
+
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		t.Run(strategy.String(), func(t *testing.T) {
+			t.Parallel()
+
+			before, ok := cornerPivots[strategy]
+			require.True(t, ok)
+
+			boxes, fill := packSeeded(strategy)
+			t.Logf("boxes %d -> %d, fill %.2f%% -> %.2f%%", before.boxes, boxes, before.fill, fill)
+			require.LessOrEqual(t, boxes, before.boxes)
+			require.GreaterOrEqual(t, fill, before.fill-0.0001)
+		})
+	}
+}
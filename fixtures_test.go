@@ -0,0 +1,58 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// seededFixture returns an unlimited supply of the box and count items with random sides in [minSide, maxSide).
+// A 2D box gets 2D items.
+func seededFixture(box *boxpacker3.Box, seed uint64, count, minSide, maxSide int) ([]*boxpacker3.Box, []*boxpacker3.Item) {
+	boxes := boxpacker3.BoxesFromTypes(boxpacker3.NewBoxType(box, boxpacker3.UnlimitedQuantity))
+
+	return boxes, seededItems(seed, count, minSide, maxSide, box.Is2D())
+}
+
+// seededItems returns count items with random sides in [minSide, maxSide), 2D ones when is2D is set.
+// The sides and weights are drawn from a PCG source with the given seed, so the items are the same on every run.
+func seededItems(seed uint64, count, minSide, maxSide int, is2D bool) []*boxpacker3.Item {
+	rng := rand.New(rand.NewPCG(seed, seed)) //nolint:gosec
+	side := func() float64 { return float64(minSide + rng.IntN(maxSide-minSide)) }
+	items := make([]*boxpacker3.Item, 0, count)
+
+	for i := range count {
+		id := "item-" + strconv.Itoa(i)
+
+		if is2D {
+			items = append(items, boxpacker3.NewItem2D(id, side(), side(), 1))
+		} else {
+			items = append(items, boxpacker3.NewItem(id, side(), side(), side(), float64(1+rng.IntN(10))))
+		}
+	}
+
+	return items
+}
+
+// requireReproducible packs the input twice and requires both results to be equal.
+func requireReproducible(t *testing.T, packer *boxpacker3.Packer, boxes []*boxpacker3.Box, items []*boxpacker3.Item) {
+	t.Helper()
+
+	first, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+
+	second, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+
+	firstJSON, err := json.Marshal(first)
+	require.NoError(t, err)
+	secondJSON, err := json.Marshal(second)
+	require.NoError(t, err)
+	require.JSONEq(t, string(firstJSON), string(secondJSON), "The same seed gives the same result")
+}
@@ -0,0 +1,124 @@
+package boxpacker3
+
+import "math"
+
+// Criterion is a single metric of a custom goal together with its direction, tolerance and weight.
+// Create criteria with Minimize and Maximize and combine them with LexicographicGoal or WeightedSumGoal.
+type Criterion struct {
+	metric    Metric
+	direction direction
+	tolerance float64
+	weight    float64
+}
+
+// CriterionOption is a functional option for configuring a Criterion.
+type CriterionOption func(*Criterion)
+
+// WithCriterionTolerance sets the largest difference between two metric values that is still
+// treated as a tie. The default is the same tolerance the built-in goals use.
+func WithCriterionTolerance(tolerance float64) CriterionOption {
+	return func(c *Criterion) {
+		c.tolerance = max(tolerance, 0)
+	}
+}
+
+// WithCriterionWeight sets the weight of the criterion in WeightedSumGoal. The default is 1.
+// Lexicographic goals ignore weights.
+func WithCriterionWeight(weight float64) CriterionOption {
+	return func(c *Criterion) {
+		c.weight = weight
+	}
+}
+
+// Minimize creates a criterion where lower metric values are better.
+func Minimize(metric Metric, opts ...CriterionOption) Criterion {
+	return newCriterion(metric, lessIsBetter, opts)
+}
+
+// Maximize creates a criterion where higher metric values are better.
+func Maximize(metric Metric, opts ...CriterionOption) Criterion {
+	return newCriterion(metric, moreIsBetter, opts)
+}
+
+func newCriterion(metric Metric, d direction, opts []CriterionOption) Criterion {
+	c := Criterion{
+		metric:    metric,
+		direction: d,
+		tolerance: epsilon,
+		weight:    1,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// LexicographicGoal compares results criterion by criterion, in the given order.
+// The first criterion whose values differ by more than its tolerance decides;
+// when all criteria tie, the candidate does not replace the current best.
+//
+// Usage:
+//
+//	goal := LexicographicGoal(
+//	    Minimize(UnfitCountMetric),
+//	    Minimize(Metric(TotalBoxCost), WithCriterionTolerance(0.01)),
+//	    Maximize(AverageFillRateMetric),
+//	)
+func LexicographicGoal(criteria ...Criterion) ComparatorFunc {
+	return func(candidate, currentBest *Result) bool {
+		if currentBest == nil {
+			return true
+		}
+
+		for _, c := range criteria {
+			if cmp := compareMetric(c.metric(candidate), c.metric(currentBest), c.direction, c.tolerance); cmp != 0 {
+				return cmp < 0
+			}
+		}
+
+		return false
+	}
+}
+
+// WeightedSumGoal scores every result as the weighted sum of its criteria, where maximized
+// metrics count negatively, and prefers the lower score. The candidate wins only when its
+// score is lower by more than the weighted sum of the criteria tolerances.
+//
+// Usage:
+//
+//	goal := WeightedSumGoal(
+//	    Minimize(UnfitCountMetric, WithCriterionWeight(1000)),
+//	    Minimize(BoxCountMetric, WithCriterionWeight(10)),
+//	    Maximize(AverageFillRateMetric, WithCriterionWeight(5)),
+//	)
+func WeightedSumGoal(criteria ...Criterion) ComparatorFunc {
+	var tolerance float64
+
+	for _, c := range criteria {
+		tolerance += math.Abs(c.weight) * c.tolerance
+	}
+
+	score := func(res *Result) float64 {
+		var sum float64
+
+		for _, c := range criteria {
+			if c.direction == lessIsBetter {
+				sum += c.weight * c.metric(res)
+			} else {
+				sum -= c.weight * c.metric(res)
+			}
+		}
+
+		return sum
+	}
+
+	return func(candidate, currentBest *Result) bool {
+		if currentBest == nil {
+			return true
+		}
+
+		return compareMetric(score(candidate), score(currentBest), lessIsBetter, tolerance) < 0
+	}
+}
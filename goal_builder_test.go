@@ -0,0 +1,125 @@
+package boxpacker3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestLexicographicGoal tests criteria order, directions and tolerances.
+func TestLexicographicGoal(t *testing.T) {
+	t.Parallel()
+
+	// A: 1 box, 10% fill. B: 2 boxes, 50% fill each.
+	resA := &boxpacker3.Result{Boxes: []*boxpacker3.Box{makeBoxWithProps(100, 10, 10)}}
+	resB := &boxpacker3.Result{Boxes: []*boxpacker3.Box{makeBoxWithProps(10, 5, 5), makeBoxWithProps(10, 5, 5)}}
+
+	fewerBoxes := boxpacker3.LexicographicGoal(
+		boxpacker3.Minimize(boxpacker3.UnfitCountMetric),
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric),
+	)
+	require.True(t, fewerBoxes(resA, resB))
+	require.False(t, fewerBoxes(resB, resA))
+	require.True(t, fewerBoxes(resB, nil))
+
+	denser := boxpacker3.LexicographicGoal(boxpacker3.Maximize(boxpacker3.AverageFillRateMetric))
+	require.True(t, denser(resB, resA))
+
+	// A tolerance of 1 box turns the box count into a tie, so the fill rate decides.
+	tolerant := boxpacker3.LexicographicGoal(
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric, boxpacker3.WithCriterionTolerance(1.5)),
+		boxpacker3.Maximize(boxpacker3.AverageFillRateMetric),
+	)
+	require.True(t, tolerant(resB, resA))
+
+	// All criteria tie: the current best is kept.
+	require.False(t, fewerBoxes(resA, resA))
+}
+
+// TestLexicographicGoal_MatchesBuiltIn tests that built-in goals can be rebuilt from exported metrics.
+func TestLexicographicGoal_MatchesBuiltIn(t *testing.T) {
+	t.Parallel()
+
+	rebuilt := boxpacker3.LexicographicGoal(
+		boxpacker3.Minimize(boxpacker3.UnfitCountMetric),
+		boxpacker3.Minimize(boxpacker3.UsedVolumeMetric),
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric),
+	)
+
+	results := []*boxpacker3.Result{
+		{Boxes: []*boxpacker3.Box{makeBoxWithProps(100, 10, 10)}},
+		{Boxes: []*boxpacker3.Box{makeBoxWithProps(10, 5, 5), makeBoxWithProps(10, 5, 5)}},
+		{Boxes: []*boxpacker3.Box{makeBoxWithProps(10, 5, 5)}, UnfitItems: []*boxpacker3.Item{boxpacker3.NewItem("x", 1, 1, 1, 1)}},
+	}
+
+	for _, cand := range results {
+		for _, best := range results {
+			require.Equal(t, boxpacker3.TightestPackingGoal(cand, best), rebuilt(cand, best))
+		}
+	}
+}
+
+// TestWeightedSumGoal tests that weights trade off metrics against each other.
+func TestWeightedSumGoal(t *testing.T) {
+	t.Parallel()
+
+	// A: 1 box, 10% fill. B: 2 boxes, 50% fill each.
+	resA := &boxpacker3.Result{Boxes: []*boxpacker3.Box{makeBoxWithProps(100, 10, 10)}}
+	resB := &boxpacker3.Result{Boxes: []*boxpacker3.Box{makeBoxWithProps(10, 5, 5), makeBoxWithProps(10, 5, 5)}}
+
+	// A: 1*1 - 1*0.1 = 0.9, B: 1*2 - 1*0.5 = 1.5.
+	boxesHeavy := boxpacker3.WeightedSumGoal(
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric),
+		boxpacker3.Maximize(boxpacker3.AverageFillRateMetric),
+	)
+	require.True(t, boxesHeavy(resA, resB))
+	require.False(t, boxesHeavy(resB, resA))
+
+	// A: 1 - 10*0.1 = 0, B: 2 - 10*0.5 = -3.
+	fillHeavy := boxpacker3.WeightedSumGoal(
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric),
+		boxpacker3.Maximize(boxpacker3.AverageFillRateMetric, boxpacker3.WithCriterionWeight(10)),
+	)
+	require.True(t, fillHeavy(resB, resA))
+	require.True(t, fillHeavy(resA, nil))
+
+	// The difference of 3 is within the weighted tolerance of 1*1 + 10*0.5.
+	tolerant := boxpacker3.WeightedSumGoal(
+		boxpacker3.Minimize(boxpacker3.BoxCountMetric, boxpacker3.WithCriterionTolerance(1)),
+		boxpacker3.Maximize(boxpacker3.AverageFillRateMetric,
+			boxpacker3.WithCriterionWeight(10), boxpacker3.WithCriterionTolerance(0.5)),
+	)
+	require.False(t, tolerant(resB, resA))
+}
+
+// TestWeightedSumGoal_CustomMetric tests custom metrics and CostFunc conversion.
+func TestWeightedSumGoal_CustomMetric(t *testing.T) {
+	t.Parallel()
+
+	cheap := boxpacker3.NewBox("cheap", 10, 1, 1, 100, boxpacker3.WithCost(1))
+	pricey := boxpacker3.NewBox("pricey", 10, 1, 1, 100, boxpacker3.WithCost(3))
+
+	require.True(t, cheap.PutItem(boxpacker3.NewItem("i", 5, 1, 1, 1), boxpacker3.Pivot{}))
+	require.True(t, pricey.PutItem(boxpacker3.NewItem("i", 5, 1, 1, 1), boxpacker3.Pivot{}))
+
+	resCheap := &boxpacker3.Result{Boxes: []*boxpacker3.Box{cheap}}
+	resPricey := &boxpacker3.Result{Boxes: []*boxpacker3.Box{pricey}}
+
+	itemsPacked := func(res *boxpacker3.Result) float64 {
+		n := 0
+		for _, b := range res.Boxes {
+			n += len(b.GetItems())
+		}
+
+		return float64(n)
+	}
+
+	goal := boxpacker3.WeightedSumGoal(
+		boxpacker3.Maximize(itemsPacked, boxpacker3.WithCriterionWeight(100)),
+		boxpacker3.Minimize(boxpacker3.Metric(boxpacker3.TotalBoxCost)),
+	)
+	require.True(t, goal(resCheap, resPricey))
+	require.False(t, goal(resPricey, resCheap))
+}
@@ -1,10 +1,19 @@
 package boxpacker3
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"strings"
 )
 
-type metricFunc func(res *Result) float64
+// ErrUnknownGoal is returned when parsing an unknown goal name.
+var ErrUnknownGoal = errors.New("boxpacker3: unknown goal")
+
+// Metric extracts a numeric score from a packing result.
+// Built-in metrics are UnfitCountMetric, BoxCountMetric, UsedVolumeMetric, AverageFillRateMetric,
+// WeightStdDevMetric and UsedLengthMetric; any CostFunc can be converted to a Metric.
+type Metric func(res *Result) float64
 
 type direction int
 
@@ -16,7 +25,7 @@ const (
 const epsilon = 0.00001
 
 type criterion struct {
-	metric    metricFunc
+	metric    Metric
 	direction direction
 }
 
@@ -27,44 +36,69 @@ func makeGoal(criteria ...criterion) ComparatorFunc {
 		}
 
 		for _, c := range criteria {
-			valCand := c.metric(candidate)
-			valBest := c.metric(currentBest)
-
-			if math.Abs(valCand-valBest) < epsilon {
-				continue
-			}
-
-			if c.direction == lessIsBetter {
-				return valCand < valBest
+			if cmp := compareMetric(c.metric(candidate), c.metric(currentBest), c.direction, epsilon); cmp != 0 {
+				return cmp < 0
 			}
-
-			return valCand > valBest
 		}
 
 		return false
 	}
 }
 
-func unfitCountMetric(res *Result) float64 {
+// compareMetric returns -1 when the candidate value is better, 1 when it is worse
+// and 0 when both values are equal within the tolerance.
+func compareMetric(valCand, valBest float64, d direction, tolerance float64) int {
+	if math.Abs(valCand-valBest) < tolerance {
+		return 0
+	}
+
+	if (d == lessIsBetter) == (valCand < valBest) {
+		return -1
+	}
+
+	return 1
+}
+
+// UnfitCountMetric returns the number of items that were not packed.
+func UnfitCountMetric(res *Result) float64 {
 	return float64(len(res.UnfitItems))
 }
 
-func boxCountMetric(res *Result) float64 {
+// BoxCountMetric returns the number of boxes that contain at least one item.
+func BoxCountMetric(res *Result) float64 {
 	return float64(countUsedBoxes(res.Boxes))
 }
 
-func totalVolumeMetric(res *Result) float64 {
+// UsedVolumeMetric returns the total volume of the boxes that contain at least one item.
+// A strip counts with the volume of its consumed length.
+func UsedVolumeMetric(res *Result) float64 {
 	return getUsedVolume(res.Boxes)
 }
 
-func averageFillRateMetric(res *Result) float64 {
+// AverageFillRateMetric returns the average fill rate (see Box.GetFillRate) across used boxes.
+func AverageFillRateMetric(res *Result) float64 {
 	return getAverageFillRate(res.Boxes)
 }
 
-func weightStdDevMetric(res *Result) float64 {
+// WeightStdDevMetric returns the standard deviation of the items weight across used boxes.
+func WeightStdDevMetric(res *Result) float64 {
 	return getWeightStdDev(res.Boxes)
 }
 
+// UsedLengthMetric returns the total length taken by the items across used boxes (see Box.GetUsedLength),
+// e.g. the consumed length of fabric or paper rolls.
+func UsedLengthMetric(res *Result) float64 {
+	var length float64
+
+	for _, b := range res.Boxes {
+		if b != nil {
+			length += b.GetUsedLength()
+		}
+	}
+
+	return length
+}
+
 // MinimizeBoxesGoal prioritizes using the fewest number of boxes possible.
 // This is the classic bin packing goal, ideal for reducing shipping label costs.
 //
@@ -73,9 +107,9 @@ func weightStdDevMetric(res *Result) float64 {
 // 3. Minimize total volume of boxes used (prefer smaller boxes).
 func MinimizeBoxesGoal(candidate, currentBest *Result) bool {
 	return makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
-		criterion{boxCountMetric, lessIsBetter},
-		criterion{totalVolumeMetric, lessIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
+		criterion{UsedVolumeMetric, lessIsBetter},
 	)(candidate, currentBest)
 }
 
@@ -85,7 +119,7 @@ func MinimizeBoxesGoal(candidate, currentBest *Result) bool {
 // 1. Maximize items packed (minimize unfit items).
 func MaximizeItemsGoal(candidate, currentBest *Result) bool {
 	return makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
 	)(candidate, currentBest)
 }
 
@@ -97,9 +131,9 @@ func MaximizeItemsGoal(candidate, currentBest *Result) bool {
 // 3. Minimize number of boxes used.
 func TightestPackingGoal(candidate, currentBest *Result) bool {
 	return makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
-		criterion{totalVolumeMetric, lessIsBetter},
-		criterion{boxCountMetric, lessIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{UsedVolumeMetric, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
 	)(candidate, currentBest)
 }
 
@@ -110,8 +144,8 @@ func TightestPackingGoal(candidate, currentBest *Result) bool {
 // 2. Maximize average fill rate across used boxes.
 func MaxAverageFillRateGoal(candidate, currentBest *Result) bool {
 	return makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
-		criterion{averageFillRateMetric, moreIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{AverageFillRateMetric, moreIsBetter},
 	)(candidate, currentBest)
 }
 
@@ -123,12 +157,97 @@ func MaxAverageFillRateGoal(candidate, currentBest *Result) bool {
 // 3. Minimize number of boxes used.
 func BalancedPackingGoal(candidate, currentBest *Result) bool {
 	return makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
-		criterion{weightStdDevMetric, lessIsBetter},
-		criterion{boxCountMetric, lessIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{WeightStdDevMetric, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
 	)(candidate, currentBest)
 }
 
+// MinimizeCostGoal prioritizes the cheapest shipment, using the price of each used box (see WithCost).
+// Ideal when boxes have different prices and the box count alone does not reflect the real expense.
+//
+// 1. Maximize items packed (minimize unfit items).
+// 2. Minimize total cost (TotalBoxCost).
+// 3. Minimize number of boxes used.
+func MinimizeCostGoal(candidate, currentBest *Result) bool {
+	return NewMinimizeCostGoal(TotalBoxCost)(candidate, currentBest)
+}
+
+// NewMinimizeCostGoal creates a MinimizeCostGoal variant that uses a custom CostFunc,
+// e.g. one that adds carrier charges to the price of the boxes.
+func NewMinimizeCostGoal(cost CostFunc) ComparatorFunc {
+	return makeGoal(
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{Metric(cost), lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
+	)
+}
+
+// NewMinimizeBillableWeightGoal creates a goal that minimizes the total weight billed by a carrier,
+// where every parcel is charged max(actual weight, dimensional weight).
+// Ideal when the carrier price depends on the billable weight rather than on the number of parcels.
+//
+// 1. Maximize items packed (minimize unfit items).
+// 2. Minimize total billable weight.
+// 3. Minimize number of boxes used.
+func NewMinimizeBillableWeightGoal(calc *DimWeightCalculator) ComparatorFunc {
+	return makeGoal(
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{calc.TotalBillableWeight, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
+	)
+}
+
+// MinimizeLengthGoal prioritizes the shortest consumed length of material.
+// Ideal for strip packing on rolls (see NewStrip and SkylineStrategy).
+//
+// 1. Maximize items packed (minimize unfit items).
+// 2. Minimize total used length (UsedLengthMetric).
+// 3. Minimize number of boxes used.
+func MinimizeLengthGoal(candidate, currentBest *Result) bool {
+	return makeGoal(
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{UsedLengthMetric, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
+	)(candidate, currentBest)
+}
+
+// GoalNames returns the names of the built-in goals accepted by ParseGoal.
+func GoalNames() []string {
+	return []string{
+		"MinimizeBoxes",
+		"MaximizeItems",
+		"TightestPacking",
+		"MaxAverageFillRate",
+		"BalancedPacking",
+		"MinimizeCost",
+		"MinimizeLength",
+	}
+}
+
+// ParseGoal returns the built-in goal with the given name (case-insensitive),
+// e.g. "TightestPacking" for TightestPackingGoal.
+func ParseGoal(name string) (ComparatorFunc, error) {
+	switch strings.ToLower(name) {
+	case "minimizeboxes":
+		return MinimizeBoxesGoal, nil
+	case "maximizeitems":
+		return MaximizeItemsGoal, nil
+	case "tightestpacking":
+		return TightestPackingGoal, nil
+	case "maxaveragefillrate":
+		return MaxAverageFillRateGoal, nil
+	case "balancedpacking":
+		return BalancedPackingGoal, nil
+	case "minimizecost":
+		return MinimizeCostGoal, nil
+	case "minimizelength":
+		return MinimizeLengthGoal, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGoal, name)
+	}
+}
+
 func countUsedBoxes(boxes []*Box) int {
 	n := 0
 
@@ -146,7 +265,7 @@ func getUsedVolume(boxes []*Box) float64 {
 
 	for _, b := range boxes {
 		if len(b.items) > 0 {
-			v += b.volume
+			v += b.usedVolume()
 		}
 	}
 
@@ -160,8 +279,8 @@ func getAverageFillRate(boxes []*Box) float64 {
 	)
 
 	for _, b := range boxes {
-		if len(b.items) > 0 && b.volume > 0 {
-			totalRate += b.itemsVolume / b.volume
+		if len(b.items) > 0 && b.usedVolume() > 0 {
+			totalRate += b.GetFillRate()
 			count++
 		}
 	}
@@ -27,9 +27,9 @@ func BenchmarkMinimizeBoxesGoal_Call(b *testing.B) {
 func BenchmarkMinimizeBoxesGoal_Closure(b *testing.B) {
 	// benchmark calling the comparator closure directly (avoids recreate of closure each call)
 	comp := makeGoal(
-		criterion{unfitCountMetric, lessIsBetter},
-		criterion{boxCountMetric, lessIsBetter},
-		criterion{totalVolumeMetric, lessIsBetter},
+		criterion{UnfitCountMetric, lessIsBetter},
+		criterion{BoxCountMetric, lessIsBetter},
+		criterion{UsedVolumeMetric, lessIsBetter},
 	)
 	cand, best := benchResults()
 
@@ -160,7 +160,7 @@ func TestMakeGoalEpsilonTolerance(t *testing.T) {
 	best := &Result{UnfitItems: itemSlice{}, Boxes: boxSlice{boxB}}
 
 	// tweak values slightly around epsilon
-	if math.Abs(unfitCountMetric(cand)-unfitCountMetric(best)) < eps {
+	if math.Abs(UnfitCountMetric(cand)-UnfitCountMetric(best)) < eps {
 		// with equal unfit count and equal boxes, makeGoal should return false (no improvement)
 		require.False(t, MinimizeBoxesGoal(cand, best), "expected no preference when metrics are equal within epsilon")
 	}
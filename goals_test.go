@@ -87,6 +87,24 @@ func TestGoals_TieBreaker(t *testing.T) {
 		"MinimizeBoxes should prefer smaller volume if box counts are equal")
 }
 
+// TestGoals_StripMetrics tests that strips count with their consumed length in the volume metrics.
+func TestGoals_StripMetrics(t *testing.T) {
+	t.Parallel()
+
+	full := boxpacker3.NewStrip("full", 100, 1000)
+	require.True(t, full.PutItem(boxpacker3.NewItem2D("a", 100, 20, 1), boxpacker3.Pivot{}))
+
+	half := boxpacker3.NewStrip("half", 100, 1000)
+	require.True(t, half.PutItem(boxpacker3.NewItem2D("b", 50, 20, 1), boxpacker3.Pivot{}))
+
+	res := &boxpacker3.Result{Boxes: []*boxpacker3.Box{full, half}}
+
+	require.InDelta(t, 4000.0, boxpacker3.UsedVolumeMetric(res), 1e-9)
+	require.InDelta(t, 0.75, boxpacker3.AverageFillRateMetric(res), 1e-9)
+	require.True(t, boxpacker3.MaxAverageFillRateGoal(&boxpacker3.Result{Boxes: []*boxpacker3.Box{full}},
+		&boxpacker3.Result{Boxes: []*boxpacker3.Box{half}}), "A fully used length is denser")
+}
+
 // makeBoxWithProps creates a real Box struct and populates it with an item
 // to simulate volume and weight usage for testing goals.
 func makeBoxWithProps(volume, itemsVolume, itemsWeight float64) *boxpacker3.Box {
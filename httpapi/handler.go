@@ -0,0 +1,272 @@
+// Package httpapi exposes the packer as an HTTP/JSON service.
+//
+// A pack request is a JSON document posted to the handler:
+//
+//	{
+//	  "boxes": [{"id": "carton", "width": 100, "height": 100, "depth": 100, "maxWeight": 1000}],
+//	  "items": [{"id": "mug", "width": 10, "height": 12, "depth": 10, "weight": 0.4}],
+//	  "strategies": ["BestFitDecreasing", "MinimizeBoxes"],
+//	  "goal": "TightestPacking",
+//	  "timeoutMs": 500
+//	}
+//
+// The response is the JSON encoding of boxpacker3.Result, or {"error": "..."} on failure.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// DefaultMaxBodyBytes is the default limit for the size of a request body.
+const DefaultMaxBodyBytes = 10 << 20
+
+// StatusClientClosedRequest is written when the client cancels the request before it is packed.
+// The status is not registered with IANA; it follows the nginx convention.
+const StatusClientClosedRequest = 499
+
+// ErrSingleStrategy is returned for a goal or best effort without at least two strategies.
+var ErrSingleStrategy = errors.New("httpapi: goal and bestEffort need at least two strategies")
+
+// Request is the body of a pack request.
+type Request struct {
+	Boxes []*boxpacker3.Box  `json:"boxes"`
+	Items []*boxpacker3.Item `json:"items"`
+
+	// Strategy is a single strategy name, e.g. "BestFit".
+	Strategy string `json:"strategy,omitempty"`
+	// Strategies are run with a ParallelStrategy, or "all" for every built-in strategy.
+	Strategies []string `json:"strategies,omitempty"`
+	// Goal selects the best parallel result, MinimizeBoxes by default.
+	// It needs at least two strategies.
+	Goal string `json:"goal,omitempty"`
+	// TimeoutMs limits the packing time; it is capped by WithMaxTimeout.
+	TimeoutMs int64 `json:"timeoutMs,omitempty"`
+	// BestEffort answers with the best parallel result finished before the timeout
+	// instead of an error (see boxpacker3.WithBestEffort). It needs at least two strategies.
+	BestEffort bool `json:"bestEffort,omitempty"`
+
+	// StabilityMinSupport enables stability mode (see boxpacker3.WithStability).
+	StabilityMinSupport float64 `json:"stabilityMinSupport,omitempty"`
+}
+
+// ErrorResponse is returned with every non-2xx status.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler serves pack requests.
+type Handler struct {
+	defaultTimeout time.Duration
+	maxTimeout     time.Duration
+	maxBodyBytes   int64
+}
+
+// HandlerOption is a functional option for configuring a Handler.
+type HandlerOption func(*Handler)
+
+// WithDefaultTimeout sets the timeout used when a request does not specify one.
+// Zero (the default) means the request is only bound by the client connection.
+func WithDefaultTimeout(d time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.defaultTimeout = d
+	}
+}
+
+// WithMaxTimeout caps the timeout a request may ask for. Zero (the default) means no cap.
+func WithMaxTimeout(d time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.maxTimeout = d
+	}
+}
+
+// WithMaxBodyBytes limits the size of a request body (DefaultMaxBodyBytes by default).
+func WithMaxBodyBytes(n int64) HandlerOption {
+	return func(h *Handler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(opts ...HandlerOption) *Handler {
+	h := &Handler{
+		maxBodyBytes: DefaultMaxBodyBytes,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP decodes a Request, packs it with the context of the HTTP request
+// (bounded by the request timeout) and writes the Result.
+// Boxes and items are checked with boxpacker3.ValidateInput first.
+// An expired timeout is answered with 504, a client that went away with StatusClientClosedRequest.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed")) //nolint:err113
+
+		return
+	}
+
+	var req Request
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err := dec.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+
+			return
+		}
+
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+
+		return
+	}
+
+	strategy, err := req.PackerOption()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if timeout := h.timeout(req.TimeoutMs); timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	packer := boxpacker3.NewPacker(
+		strategy,
+		boxpacker3.WithStability(req.StabilityMinSupport),
+		boxpacker3.WithInputValidation(),
+	)
+
+	result, err := packer.PackCtx(ctx, req.Boxes, req.Items)
+
+	switch {
+	case errors.Is(err, boxpacker3.ErrInvalidInput):
+		writeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, err)
+	case errors.Is(err, context.Canceled):
+		writeError(w, StatusClientClosedRequest, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// PackerOption resolves the strategies and goal of the request into a packer option.
+// A single strategy, or none, is set with WithStrategy, so 2D inputs are packed by the same
+// engines as by the library; several strategies run in a ParallelStrategy.
+// Goal and BestEffort only choose between parallel results, so they are rejected
+// with ErrSingleStrategy when the request has fewer than two strategies.
+func (r *Request) PackerOption() (boxpacker3.PackerOption, error) {
+	strategies, err := r.strategies()
+	if err != nil {
+		return nil, err
+	}
+
+	goal := boxpacker3.ComparatorFunc(boxpacker3.MinimizeBoxesGoal)
+
+	if r.Goal != "" {
+		if goal, err = boxpacker3.ParseGoal(r.Goal); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(strategies) < 2 && (r.Goal != "" || r.BestEffort) { //nolint:mnd
+		return nil, ErrSingleStrategy
+	}
+
+	switch len(strategies) {
+	case 0:
+		return boxpacker3.WithStrategy(boxpacker3.StrategyMinimizeBoxes), nil
+	case 1:
+		return boxpacker3.WithStrategy(strategies[0]), nil
+	}
+
+	algorithms := make([]boxpacker3.PackingAlgorithm, 0, len(strategies))
+	for _, s := range strategies {
+		algorithms = append(algorithms, boxpacker3.NewAlgorithm(s))
+	}
+
+	opts := []boxpacker3.ParallelOption{
+		boxpacker3.WithAlgorithms(algorithms...),
+		boxpacker3.WithGoal(goal),
+	}
+
+	if r.BestEffort {
+		opts = append(opts, boxpacker3.WithBestEffort())
+	}
+
+	return boxpacker3.WithAlgorithm(boxpacker3.NewParallelStrategy(opts...)), nil
+}
+
+// strategies parses the strategy names of the request, "all" expands to every built-in strategy.
+func (r *Request) strategies() ([]boxpacker3.PackingStrategy, error) {
+	names := r.Strategies
+	if r.Strategy != "" {
+		names = append([]string{r.Strategy}, names...)
+	}
+
+	var strategies []boxpacker3.PackingStrategy
+
+	for _, name := range names {
+		if strings.EqualFold(name, "all") {
+			strategies = append(strategies, boxpacker3.PackingStrategies()...)
+
+			continue
+		}
+
+		s, err := boxpacker3.ParsePackingStrategy(name)
+		if err != nil {
+			return nil, err
+		}
+
+		strategies = append(strategies, s)
+	}
+
+	return strategies, nil
+}
+
+func (h *Handler) timeout(requestedMs int64) time.Duration {
+	timeout := h.defaultTimeout
+	if requestedMs > 0 {
+		timeout = time.Duration(requestedMs) * time.Millisecond
+	}
+
+	if h.maxTimeout > 0 && (timeout <= 0 || timeout > h.maxTimeout) {
+		timeout = h.maxTimeout
+	}
+
+	return timeout
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,173 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+	"github.com/bavix/boxpacker3/httpapi"
+)
+
+const order = `{
+	"boxes": [
+		{"id": "small", "width": 50, "height": 50, "depth": 50, "maxWeight": 100},
+		{"id": "large", "width": 100, "height": 100, "depth": 100, "maxWeight": 100}
+	],
+	"items": [
+		{"id": "a", "width": 40, "height": 40, "depth": 40, "weight": 1},
+		{"id": "b", "width": 200, "height": 1, "depth": 1, "weight": 1}
+	],
+	%s
+}`
+
+func post(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/pack", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// TestHandler_Pack tests packing with a single strategy and with a parallel strategy and goal.
+func TestHandler_Pack(t *testing.T) {
+	t.Parallel()
+
+	for _, options := range []string{
+		`"strategy": "BestFit"`,
+		`"strategies": ["all"], "goal": "TightestPacking", "timeoutMs": 1000`,
+		`"stabilityMinSupport": 0.5`,
+	} {
+		rec := post(t, httpapi.NewHandler(), strings.Replace(order, "%s", options, 1))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var result boxpacker3.Result
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Len(t, result.UnfitItems, 1)
+		require.Equal(t, "b", result.UnfitItems[0].GetID())
+
+		used := 0
+
+		for _, box := range result.Boxes {
+			if len(box.GetItems()) > 0 {
+				used++
+
+				require.Equal(t, "small", box.GetID(), options)
+			}
+		}
+
+		require.Equal(t, 1, used)
+	}
+}
+
+// TestHandler_Errors tests the status codes of invalid requests.
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		handler *httpapi.Handler
+		body    string
+		status  int
+	}{
+		{"bad json", httpapi.NewHandler(), "{", http.StatusBadRequest},
+		{"unknown strategy", httpapi.NewHandler(), `{"strategy": "Magic"}`, http.StatusBadRequest},
+		{"unknown goal", httpapi.NewHandler(), `{"strategies": ["Greedy", "BestFit"], "goal": "Magic"}`, http.StatusBadRequest},
+		{"unknown goal of one strategy", httpapi.NewHandler(), `{"strategy": "Greedy", "goal": "Magic"}`, http.StatusBadRequest},
+		{"goal of one strategy", httpapi.NewHandler(), `{"strategy": "Greedy", "goal": "TightestPacking"}`,
+			http.StatusBadRequest},
+		{"best effort without strategies", httpapi.NewHandler(), `{"bestEffort": true}`, http.StatusBadRequest},
+		{"invalid item", httpapi.NewHandler(), `{"items": [{"id": "i", "width": -1, "height": 1, "depth": 1}]}`,
+			http.StatusBadRequest},
+		{"too large", httpapi.NewHandler(httpapi.WithMaxBodyBytes(10)), strings.Replace(order, "%s", `"goal": ""`, 1),
+			http.StatusRequestEntityTooLarge},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := post(t, c.handler, c.body)
+			require.Equal(t, c.status, rec.Code)
+
+			var resp httpapi.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.NotEmpty(t, resp.Error)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/pack", nil)
+	rec := httptest.NewRecorder()
+	httpapi.NewHandler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+}
+
+// TestHandler_Timeout tests that the request context is propagated to the packer.
+func TestHandler_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodPost, "/pack", strings.NewReader(strings.Replace(order, "%s", `"timeoutMs": 1000`, 1)))
+	rec := httptest.NewRecorder()
+	httpapi.NewHandler(httpapi.WithMaxTimeout(time.Hour)).ServeHTTP(rec, req.WithContext(ctx))
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code, rec.Body.String())
+}
+
+// TestHandler_Canceled tests that a request canceled by the client is not reported as a server error.
+func TestHandler_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodPost, "/pack", strings.NewReader(strings.Replace(order, "%s", `"strategy": "BestFit"`, 1)))
+	rec := httptest.NewRecorder()
+	httpapi.NewHandler().ServeHTTP(rec, req.WithContext(ctx))
+	require.Equal(t, httpapi.StatusClientClosedRequest, rec.Code, rec.Body.String())
+}
+
+// TestHandler_Sheets tests that a single strategy packs 2D input with the 2D engine, as the library does.
+func TestHandler_Sheets(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+		"boxes": [{"id": "sheet", "width": 100, "height": 100, "depth": 1, "maxWeight": 1000}],
+		"items": [
+			{"id": "a", "width": 60, "height": 30, "depth": 1, "weight": 1},
+			{"id": "b", "width": 70, "height": 40, "depth": 1, "weight": 1},
+			{"id": "c", "width": 30, "height": 50, "depth": 1, "weight": 1},
+			{"id": "d", "width": 40, "height": 60, "depth": 1, "weight": 1}
+		]
+	}`
+
+	expected, err := boxpacker3.NewPacker().PackCtx(context.Background(),
+		[]*boxpacker3.Box{boxpacker3.NewBox2D("sheet", 100, 100, 1000)},
+		[]*boxpacker3.Item{
+			boxpacker3.NewItem2D("a", 60, 30, 1),
+			boxpacker3.NewItem2D("b", 70, 40, 1),
+			boxpacker3.NewItem2D("c", 30, 50, 1),
+			boxpacker3.NewItem2D("d", 40, 60, 1),
+		})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	for _, options := range []string{`"goal": ""`, `"strategy": "MinimizeBoxes"`} {
+		rec := post(t, httpapi.NewHandler(), strings.Replace(body, "\n\t}", ",\n\t\t"+options+"\n\t}", 1))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.JSONEq(t, string(data), rec.Body.String(), options)
+	}
+}
@@ -0,0 +1,165 @@
+package boxpacker3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidInput is matched by every error returned by ValidateInput.
+	ErrInvalidInput = errors.New("boxpacker3: invalid input")
+	// ErrInvalidDimension is returned for a zero, negative, NaN or infinite width, height or depth.
+	ErrInvalidDimension = errors.New("boxpacker3: invalid dimension")
+	// ErrInvalidWeight is returned for a negative, NaN or infinite weight, a non-positive or NaN
+	// maximum weight, or an invalid maximum load of an item or an axle.
+	ErrInvalidWeight = errors.New("boxpacker3: invalid weight")
+	// ErrInvalidCost is returned for a negative, NaN or infinite box cost.
+	ErrInvalidCost = errors.New("boxpacker3: invalid cost")
+	// ErrDuplicateID is returned when two items that are not units of the same ItemLine,
+	// or two boxes that are not instances of the same BoxType, share an id.
+	ErrDuplicateID = errors.New("boxpacker3: duplicate id")
+	// ErrNilInput is returned for nil boxes or items.
+	ErrNilInput = errors.New("boxpacker3: nil input")
+)
+
+const (
+	inputKindBox  = "box"
+	inputKindItem = "item"
+)
+
+// InputError describes an invalid box or item.
+// It matches ErrInvalidInput and its cause (e.g. ErrInvalidDimension) with errors.Is.
+type InputError struct {
+	// Kind is "box" or "item".
+	Kind string
+	// ID is the id of the offending box or item.
+	ID string
+	// Index is the position of the box or item in the input slice.
+	Index int
+	// Field is the name of the invalid field, e.g. "width"; empty for ErrDuplicateID and ErrNilInput.
+	Field string
+	Value float64
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *InputError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%v: %s %q (#%d)", e.Err, e.Kind, e.ID, e.Index)
+	}
+
+	return fmt.Sprintf("%v: %s %q (#%d) %s = %g", e.Err, e.Kind, e.ID, e.Index, e.Field, e.Value)
+}
+
+// Unwrap returns the cause of the error.
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidInput.
+func (e *InputError) Is(target error) bool {
+	return target == ErrInvalidInput //nolint:errorlint,err113
+}
+
+// WithInputValidation makes PackCtx check the boxes and items with ValidateInput before packing
+// and return the validation error instead of a result.
+func WithInputValidation() PackerOption {
+	return func(p *Packer) {
+		p.validateInput = true
+	}
+}
+
+// ValidateInput checks boxes and items before packing.
+//
+// It returns nil for valid input, otherwise every problem found joined with errors.Join.
+// Each problem is an *InputError, so the offending ids can be inspected with errors.As,
+// and the cause with errors.Is (ErrInvalidDimension, ErrInvalidWeight, ErrInvalidCost, ErrDuplicateID, ErrNilInput).
+func ValidateInput(boxes []*Box, items []*Item) error {
+	var errs []error
+
+	boxTypes := make(map[string]*BoxType, len(boxes))
+
+	for i, b := range boxes {
+		if b == nil {
+			errs = append(errs, &InputError{Kind: inputKindBox, Index: i, Err: ErrNilInput})
+
+			continue
+		}
+
+		fail := func(field string, value float64, err error) {
+			errs = append(errs, &InputError{Kind: inputKindBox, ID: b.id, Index: i, Field: field, Value: value, Err: err})
+		}
+
+		checkDimensions(fail, b.width, b.height, b.depth)
+
+		if math.IsNaN(b.maxWeight) || b.maxWeight <= 0 {
+			fail("maxWeight", b.maxWeight, ErrInvalidWeight)
+		}
+
+		if !isFinite(b.cost) || b.cost < 0 {
+			fail("cost", b.cost, ErrInvalidCost)
+		}
+
+		for _, axle := range b.axles {
+			if math.IsNaN(axle.MaxLoad) || axle.MaxLoad < 0 {
+				fail("axleMaxLoad", axle.MaxLoad, ErrInvalidWeight)
+			}
+		}
+
+		// Instances of the same box type share the id of the type.
+		if bt, seen := boxTypes[b.id]; seen && (bt == nil || bt != b.boxType) {
+			fail("", 0, ErrDuplicateID)
+		}
+
+		boxTypes[b.id] = b.boxType
+	}
+
+	lines := make(map[string]*ItemLine, len(items))
+
+	for i, it := range items {
+		if it == nil {
+			errs = append(errs, &InputError{Kind: inputKindItem, Index: i, Err: ErrNilInput})
+
+			continue
+		}
+
+		fail := func(field string, value float64, err error) {
+			errs = append(errs, &InputError{Kind: inputKindItem, ID: it.id, Index: i, Field: field, Value: value, Err: err})
+		}
+
+		checkDimensions(fail, it.whd[WidthAxis], it.whd[HeightAxis], it.whd[DepthAxis])
+
+		if !isFinite(it.weight) || it.weight < 0 {
+			fail("weight", it.weight, ErrInvalidWeight)
+		}
+
+		if it.loadLimited && math.IsNaN(it.maxLoad) {
+			fail("maxLoad", it.maxLoad, ErrInvalidWeight)
+		}
+
+		// Units of the same order line share the id of the line.
+		if line, seen := lines[it.id]; seen && (line == nil || line != it.line) {
+			fail("", 0, ErrDuplicateID)
+		}
+
+		lines[it.id] = it.line
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkDimensions(fail func(field string, value float64, err error), w, h, d float64) {
+	for _, dim := range []struct {
+		field string
+		value float64
+	}{{"width", w}, {"height", h}, {"depth", d}} {
+		if !isFinite(dim.value) || dim.value <= 0 {
+			fail(dim.field, dim.value, ErrInvalidDimension)
+		}
+	}
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,107 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestValidateInput_Valid tests that valid input, including box type instances sharing an id, passes.
+func TestValidateInput_Valid(t *testing.T) {
+	t.Parallel()
+
+	carton := boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 10, 10, 10, 100), 2)
+	boxes := []*boxpacker3.Box{carton.NewBox(), carton.NewBox(), boxpacker3.NewBox("crate", 20, 20, 20, 100)}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("a", 1, 1, 1, 0),
+		boxpacker3.NewItem("b", 1, 1, 1, 1, boxpacker3.WithFragile()),
+	}
+
+	require.NoError(t, boxpacker3.ValidateInput(boxes, items))
+	require.NoError(t, boxpacker3.ValidateInput(nil, nil))
+}
+
+// TestValidateInput_Errors tests that every invalid field is reported with its id.
+func TestValidateInput_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		boxes []*boxpacker3.Box
+		items []*boxpacker3.Item
+		cause error
+		kind  string
+		id    string
+		field string
+	}{
+		{"zero width", nil, []*boxpacker3.Item{boxpacker3.NewItem("i", 0, 1, 1, 1)},
+			boxpacker3.ErrInvalidDimension, "item", "i", "width"},
+		{"negative depth", []*boxpacker3.Box{boxpacker3.NewBox("b", 1, 1, -1, 1)}, nil,
+			boxpacker3.ErrInvalidDimension, "box", "b", "depth"},
+		{"nan height", nil, []*boxpacker3.Item{boxpacker3.NewItem("i", 1, math.NaN(), 1, 1)},
+			boxpacker3.ErrInvalidDimension, "item", "i", "height"},
+		{"inf width", []*boxpacker3.Box{boxpacker3.NewBox("b", math.Inf(1), 1, 1, 1)}, nil,
+			boxpacker3.ErrInvalidDimension, "box", "b", "width"},
+		{"negative weight", nil, []*boxpacker3.Item{boxpacker3.NewItem("i", 1, 1, 1, -1)},
+			boxpacker3.ErrInvalidWeight, "item", "i", "weight"},
+		{"zero max weight", []*boxpacker3.Box{boxpacker3.NewBox("b", 1, 1, 1, 0)}, nil,
+			boxpacker3.ErrInvalidWeight, "box", "b", "maxWeight"},
+		{"nan max load", nil, []*boxpacker3.Item{boxpacker3.NewItem("i", 1, 1, 1, 1, boxpacker3.WithMaxLoad(math.NaN()))},
+			boxpacker3.ErrInvalidWeight, "item", "i", "maxLoad"},
+		{"negative cost", []*boxpacker3.Box{boxpacker3.NewBox("b", 1, 1, 1, 1, boxpacker3.WithCost(-1))}, nil,
+			boxpacker3.ErrInvalidCost, "box", "b", "cost"},
+		{"duplicate item", nil, []*boxpacker3.Item{boxpacker3.NewItem("i", 1, 1, 1, 1), boxpacker3.NewItem("i", 1, 1, 1, 1)},
+			boxpacker3.ErrDuplicateID, "item", "i", ""},
+		{"duplicate box", []*boxpacker3.Box{boxpacker3.NewBox("b", 1, 1, 1, 1), boxpacker3.NewBox("b", 1, 1, 1, 1)}, nil,
+			boxpacker3.ErrDuplicateID, "box", "b", ""},
+		{"nil item", nil, []*boxpacker3.Item{nil}, boxpacker3.ErrNilInput, "item", "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := boxpacker3.ValidateInput(c.boxes, c.items)
+			require.ErrorIs(t, err, boxpacker3.ErrInvalidInput)
+			require.ErrorIs(t, err, c.cause)
+
+			var inputErr *boxpacker3.InputError
+			require.ErrorAs(t, err, &inputErr)
+			require.Equal(t, c.kind, inputErr.Kind)
+			require.Equal(t, c.id, inputErr.ID)
+			require.Equal(t, c.field, inputErr.Field)
+			require.NotEmpty(t, inputErr.Error())
+		})
+	}
+}
+
+// TestPacker_WithInputValidation tests that validation is opt-in and reports every problem.
+func TestPacker_WithInputValidation(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{boxpacker3.NewBox("box", 10, 10, 10, 100)}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("bad", -1, 1, 1, 1),
+		boxpacker3.NewItem("dup", 1, 1, 1, 1),
+		boxpacker3.NewItem("dup", 1, 1, 1, 1),
+	}
+
+	// Legacy callers are unaffected.
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	result, err = boxpacker3.NewPacker(boxpacker3.WithInputValidation()).PackCtx(context.Background(), boxes, items)
+	require.Nil(t, result)
+	require.ErrorIs(t, err, boxpacker3.ErrInvalidDimension)
+	require.ErrorIs(t, err, boxpacker3.ErrDuplicateID)
+
+	var joined interface{ Unwrap() []error }
+	require.True(t, errors.As(err, &joined))
+	require.Len(t, joined.Unwrap(), 2)
+}
@@ -10,6 +10,45 @@ type Item struct {
 	maxLength    float64
 	rotationType RotationType
 	position     Pivot
+
+	rotations uint8 // bitmask of allowed RotationType values, 0 means all rotations are allowed
+
+	maxLoad     float64
+	loadLimited bool
+
+	unfitReason UnfitReason
+
+	line *ItemLine
+	unit int
+}
+
+// ItemOption is a functional option for configuring an Item.
+type ItemOption func(*Item)
+
+// WithAllowedRotations restricts the orientations PutItem may choose for the item.
+// Unknown rotation types are ignored; calling it without rotations lifts the restriction.
+func WithAllowedRotations(rotations ...RotationType) ItemOption {
+	return func(i *Item) {
+		i.rotations = 0
+
+		for _, rt := range rotations {
+			if rt >= RotationTypeWhd && rt <= RotationTypeWdh {
+				i.rotations |= 1 << rt
+			}
+		}
+	}
+}
+
+// WithoutRotation keeps the item in its original orientation.
+func WithoutRotation() ItemOption {
+	return WithAllowedRotations(RotationTypeWhd)
+}
+
+// WithThisSideUp keeps the original height of the item along HeightAxis,
+// so the item may only be turned around its vertical axis.
+// Useful for liquids, TVs and anything labelled "this side up".
+func WithThisSideUp() ItemOption {
+	return WithAllowedRotations(RotationTypeWhd, RotationTypeDhw)
 }
 
 type itemSlice []*Item
@@ -35,22 +74,33 @@ func (it itemSlice) Swap(i, j int) {
 }
 
 // NewItem creates a new item with the given parameters.
-func NewItem(id string, w, h, d, wg float64) *Item {
+func NewItem(id string, w, h, d, wg float64, opts ...ItemOption) *Item {
 	//nolint:exhaustruct
-	return &Item{
+	item := &Item{
 		id:        id,
 		whd:       [3]float64{w, h, d},
 		weight:    wg,
 		volume:    w * h * d,
 		maxLength: max(w, h, d),
 	}
+
+	for _, opt := range opts {
+		opt(item)
+	}
+
+	return item
 }
 
 // NewItem2D creates a new 2D item with the given parameters.
 // The depth is set to 1, making it effectively 2D (width x height).
 // This is useful for packing flat items like sheets, boards, or panels.
-func NewItem2D(id string, w, h, wg float64) *Item {
-	return NewItem(id, w, h, 1, wg)
+func NewItem2D(id string, w, h, wg float64, opts ...ItemOption) *Item {
+	return NewItem(id, w, h, 1, wg, opts...)
+}
+
+// Is2D reports whether the item is 2D, i.e. its depth is 1 as set by NewItem2D.
+func (i *Item) Is2D() bool {
+	return i.whd[DepthAxis] == 1
 }
 
 func (i *Item) GetID() string {
@@ -81,6 +131,28 @@ func (i *Item) GetPosition() Pivot {
 	return i.position
 }
 
+// GetRotationType returns the orientation chosen when the item was placed.
+func (i *Item) GetRotationType() RotationType {
+	return i.rotationType
+}
+
+// GetAllowedRotations returns the orientations the item may be placed in.
+func (i *Item) GetAllowedRotations() []RotationType {
+	rotations := make([]RotationType, 0, len(rotationMatrix))
+
+	for rt := RotationTypeWhd; rt <= RotationTypeWdh; rt++ {
+		if i.allowsRotation(rt) {
+			rotations = append(rotations, rt)
+		}
+	}
+
+	return rotations
+}
+
+func (i *Item) allowsRotation(rt RotationType) bool {
+	return i.rotations == 0 || i.rotations&(1<<rt) != 0
+}
+
 func (i *Item) setRotationType(rt RotationType) {
 	i.rotationType = rt
 }
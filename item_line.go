@@ -0,0 +1,129 @@
+package boxpacker3
+
+import "context"
+
+// ItemLine describes an order line: a quantity of identical units of one SKU, e.g. "40 x mug".
+//
+// Instead of creating one Item with a unique id per unit, pass the lines to Packer.PackLinesCtx
+// (or expand them with ItemsFromLines). The line itself stores a single prototype; PackLinesCtx
+// expands it into one Item per unit before the strategy runs, so packing costs the same as for
+// plain items. Every unit shares the id of the line, and GetItemLine and GetUnit tell the units apart.
+//
+// Because of the shared id, ValidateInput accepts the units of one line but reports any other item
+// with the same id, even a plain NewItem, as ErrDuplicateID.
+type ItemLine struct {
+	prototype *Item
+	quantity  int
+}
+
+// NewItemLine creates an order line from a prototype item.
+// The prototype is copied, so it can be safely reused by the caller. A negative quantity is treated as 0.
+func NewItemLine(prototype *Item, quantity int) *ItemLine {
+	if prototype == nil {
+		return nil
+	}
+
+	proto := CopyPtr(prototype)
+	proto.line = nil
+	proto.unit = 0
+	proto.position = Pivot{}
+	proto.rotationType = RotationTypeWhd
+	proto.unfitReason = UnfitReasonNone
+
+	return &ItemLine{
+		prototype: proto,
+		quantity:  max(quantity, 0),
+	}
+}
+
+// ItemsFromLines expands the lines into one item per unit.
+func ItemsFromLines(lines ...*ItemLine) []*Item {
+	n := 0
+
+	for _, l := range lines {
+		if l != nil {
+			n += l.quantity
+		}
+	}
+
+	items := make([]*Item, 0, n)
+
+	for _, l := range lines {
+		if l != nil {
+			items = append(items, l.Units()...)
+		}
+	}
+
+	return items
+}
+
+// GetID returns the SKU id, shared by every unit of the line.
+func (l *ItemLine) GetID() string {
+	return l.prototype.id
+}
+
+// GetQuantity returns the number of units in the line.
+func (l *ItemLine) GetQuantity() int {
+	return l.quantity
+}
+
+// Units creates a fresh item for every unit of the line.
+func (l *ItemLine) Units() []*Item {
+	units := make([]*Item, l.quantity)
+
+	for n := range units {
+		unit := CopyPtr(l.prototype)
+		unit.line = l
+		unit.unit = n
+		units[n] = unit
+	}
+
+	return units
+}
+
+// GetItemLine returns the order line the item is a unit of, or nil for items created with NewItem.
+func (i *Item) GetItemLine() *ItemLine {
+	return i.line
+}
+
+// GetUnit returns the index of the unit within its order line, starting at 0.
+func (i *Item) GetUnit() int {
+	return i.unit
+}
+
+// GetItemCounts returns the number of items in the box per id, i.e. per SKU for order lines.
+func (b *Box) GetItemCounts() map[string]int {
+	return countByID(b.items)
+}
+
+// GetUnfitCounts returns the number of unfit items per id, i.e. per SKU for order lines.
+func (r *Result) GetUnfitCounts() map[string]int {
+	return countByID(r.UnfitItems)
+}
+
+// PackLinesCtx packs order lines into boxes, expanding every line into its units up front.
+// It behaves like PackCtx otherwise; the Index of an InputError refers to the expanded units,
+// in the order of the lines.
+func (p *Packer) PackLinesCtx(ctx context.Context, inputBoxes []*Box, lines []*ItemLine) (*Result, error) {
+	items := ItemsFromLines(lines...)
+
+	if p.validateInput {
+		if err := ValidateInput(inputBoxes, items); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.pack(ctx, inputBoxes, items)
+}
+
+func countByID(items []*Item) map[string]int {
+	counts := make(map[string]int)
+
+	for _, it := range items {
+		if it != nil {
+			counts[it.id]++
+		}
+	}
+
+	return counts
+}
@@ -0,0 +1,122 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestItemLine_Units tests that a line expands into units sharing the SKU id.
+func TestItemLine_Units(t *testing.T) {
+	t.Parallel()
+
+	proto := boxpacker3.NewItem("mug", 10, 12, 10, 0.4, boxpacker3.WithThisSideUp())
+	line := boxpacker3.NewItemLine(proto, 3)
+
+	require.Equal(t, "mug", line.GetID())
+	require.Equal(t, 3, line.GetQuantity())
+	require.Nil(t, proto.GetItemLine())
+	require.Nil(t, boxpacker3.NewItemLine(nil, 1))
+	require.Equal(t, 0, boxpacker3.NewItemLine(proto, -1).GetQuantity())
+
+	units := boxpacker3.ItemsFromLines(line, nil, boxpacker3.NewItemLine(boxpacker3.NewItem("plate", 20, 2, 20, 1), 2))
+	require.Len(t, units, 5)
+
+	for n, unit := range units[:3] {
+		require.Equal(t, "mug", unit.GetID())
+		require.Same(t, line, unit.GetItemLine())
+		require.Equal(t, n, unit.GetUnit())
+		require.Equal(t, proto.GetAllowedRotations(), unit.GetAllowedRotations())
+	}
+
+	require.NotSame(t, units[0], units[1])
+}
+
+// TestPacker_PackLinesCtx tests packing order lines and counting units per SKU.
+func TestPacker_PackLinesCtx(t *testing.T) {
+	t.Parallel()
+
+	lines := []*boxpacker3.ItemLine{
+		boxpacker3.NewItemLine(boxpacker3.NewItem("mug", 10, 10, 10, 1), 40),
+		boxpacker3.NewItemLine(boxpacker3.NewItem("plate", 20, 20, 2, 1), 5),
+	}
+	boxes := boxpacker3.BoxesFromTypes(boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 30, 30, 30, 20), 2))
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithInputValidation())
+	result, err := packer.PackLinesCtx(context.Background(), boxes, lines)
+	require.NoError(t, err)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, boxpacker3.ItemsFromLines(lines...), result))
+
+	total := result.GetUnfitCounts()
+
+	for _, box := range result.Boxes {
+		for sku, n := range box.GetItemCounts() {
+			total[sku] += n
+		}
+
+		for _, unit := range box.GetItems() {
+			require.NotNil(t, unit.GetItemLine())
+		}
+	}
+
+	require.Equal(t, map[string]int{"mug": 40, "plate": 5}, total)
+	require.Len(t, result.UnfitItems, 5, "Two cartons hold 40 units by weight")
+}
+
+// TestItemLine_DuplicateID tests that only units of the same line may share an id.
+func TestItemLine_DuplicateID(t *testing.T) {
+	t.Parallel()
+
+	a := boxpacker3.NewItemLine(boxpacker3.NewItem("mug", 1, 1, 1, 1), 2)
+	b := boxpacker3.NewItemLine(boxpacker3.NewItem("mug", 1, 1, 1, 1), 1)
+
+	require.NoError(t, boxpacker3.ValidateInput(nil, boxpacker3.ItemsFromLines(a)))
+	require.ErrorIs(t, boxpacker3.ValidateInput(nil, boxpacker3.ItemsFromLines(a, b)), boxpacker3.ErrDuplicateID)
+
+	plain := append(boxpacker3.ItemsFromLines(a), boxpacker3.NewItem("mug", 1, 1, 1, 1))
+	require.ErrorIs(t, boxpacker3.ValidateInput(nil, plain), boxpacker3.ErrDuplicateID)
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithInputValidation())
+	_, err := packer.PackLinesCtx(context.Background(), nil, []*boxpacker3.ItemLine{a, b})
+
+	var inputErr *boxpacker3.InputError
+	require.ErrorAs(t, err, &inputErr)
+	require.Equal(t, "mug", inputErr.ID)
+	require.Equal(t, 2, inputErr.Index, "The index refers to the expanded units")
+}
+
+// TestItemLine_JSON tests that units keep their line and index in JSON and share the line again.
+func TestItemLine_JSON(t *testing.T) {
+	t.Parallel()
+
+	line := boxpacker3.NewItemLine(boxpacker3.NewItem("mug", 10, 10, 10, 1), 3)
+	result, err := boxpacker3.NewPacker().PackLinesCtx(context.Background(),
+		[]*boxpacker3.Box{boxpacker3.NewBox("box", 20, 10, 10, 100)}, []*boxpacker3.ItemLine{line})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"itemCounts":{"mug":2}`)
+
+	var restored boxpacker3.Result
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	items := append(restored.Boxes[0].GetItems(), restored.UnfitItems...)
+	require.Len(t, items, 3)
+
+	units := map[int]bool{}
+
+	for _, it := range items {
+		require.Same(t, items[0].GetItemLine(), it.GetItemLine())
+		require.Equal(t, 3, it.GetItemLine().GetQuantity())
+		require.Equal(t, "mug", it.GetItemLine().GetID())
+
+		units[it.GetUnit()] = true
+	}
+
+	require.Len(t, units, 3)
+}
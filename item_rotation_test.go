@@ -1,6 +1,7 @@
 package boxpacker3_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -77,3 +78,91 @@ func TestItem_WhdArray_EdgeCases(t *testing.T) {
 	require.InDelta(t, 5.0, item.GetHeight(), 0.0001, "Equal dimensions should work")
 	require.InDelta(t, 5.0, item.GetDepth(), 0.0001, "Equal dimensions should work")
 }
+
+// TestItem_WithoutRotation tests that an item without rotation keeps its original orientation.
+func TestItem_WithoutRotation(t *testing.T) {
+	t.Parallel()
+
+	// The item only fits the box when lying on its side, which is forbidden.
+	box := boxpacker3.NewBox("box", 30, 10, 30, 1000)
+	item := boxpacker3.NewItem("tv", 10, 30, 5, 1, boxpacker3.WithoutRotation())
+
+	require.Equal(t, []boxpacker3.RotationType{boxpacker3.RotationTypeWhd}, item.GetAllowedRotations())
+	require.False(t, box.PutItem(item, boxpacker3.Pivot{}), "Item must not be rotated to fit")
+
+	free := boxpacker3.NewItem("free", 10, 30, 5, 1)
+	require.True(t, box.PutItem(free, boxpacker3.Pivot{}), "Unrestricted item may be rotated")
+	require.NotEqual(t, boxpacker3.RotationTypeWhd, free.GetRotationType())
+}
+
+// TestItem_WithThisSideUp tests that "this side up" items keep their height vertical.
+func TestItem_WithThisSideUp(t *testing.T) {
+	t.Parallel()
+
+	// Only the depth/width swap keeps the height on the vertical axis and fits the box.
+	box := boxpacker3.NewBox("box", 30, 20, 10, 1000)
+	item := boxpacker3.NewItem("liquid", 10, 20, 30, 1, boxpacker3.WithThisSideUp())
+
+	require.True(t, box.PutItem(item, boxpacker3.Pivot{}))
+	require.Equal(t, boxpacker3.RotationTypeDhw, item.GetRotationType())
+	require.Equal(t, "DHW", item.GetRotationType().String())
+	require.InDelta(t, 20.0, item.GetDimension()[boxpacker3.HeightAxis], 0.0001)
+}
+
+// TestItem_WithAllowedRotations tests the allowed rotations accessor and its defaults.
+func TestItem_WithAllowedRotations(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, boxpacker3.NewItem("any", 1, 2, 3, 1).GetAllowedRotations(), 6)
+
+	item := boxpacker3.NewItem("custom", 1, 2, 3, 1,
+		boxpacker3.WithAllowedRotations(boxpacker3.RotationTypeHwd, boxpacker3.RotationTypeWdh, boxpacker3.RotationType(42)))
+	require.Equal(t, []boxpacker3.RotationType{boxpacker3.RotationTypeHwd, boxpacker3.RotationTypeWdh}, item.GetAllowedRotations())
+
+	item = boxpacker3.NewItem2D("sheet", 1, 2, 1, boxpacker3.WithoutRotation())
+	require.Equal(t, []boxpacker3.RotationType{boxpacker3.RotationTypeWhd}, item.GetAllowedRotations())
+}
+
+// TestItem_RotationConstraints_AllStrategies tests that every strategy honors rotation constraints.
+func TestItem_RotationConstraints_AllStrategies(t *testing.T) {
+	t.Parallel()
+
+	strategies := []boxpacker3.PackingStrategy{
+		boxpacker3.StrategyMinimizeBoxes,
+		boxpacker3.StrategyGreedy,
+		boxpacker3.StrategyBestFit,
+		boxpacker3.StrategyBestFitDecreasing,
+		boxpacker3.StrategyNextFit,
+		boxpacker3.StrategyWorstFit,
+		boxpacker3.StrategyAlmostWorstFit,
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategyName(strategy), func(t *testing.T) {
+			t.Parallel()
+
+			boxes := []*boxpacker3.Box{
+				boxpacker3.NewBox("flat", 100, 20, 100, 1000),
+				boxpacker3.NewBox("tall", 100, 100, 100, 1000),
+			}
+			items := []*boxpacker3.Item{
+				boxpacker3.NewItem("upright-1", 10, 50, 10, 1, boxpacker3.WithThisSideUp()),
+				boxpacker3.NewItem("upright-2", 10, 50, 10, 1, boxpacker3.WithThisSideUp()),
+				boxpacker3.NewItem("fixed", 40, 60, 10, 1, boxpacker3.WithoutRotation()),
+			}
+
+			packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy))
+			result, err := packer.PackCtx(context.Background(), boxes, items)
+			require.NoError(t, err)
+			require.Empty(t, result.UnfitItems)
+
+			for _, box := range result.Boxes {
+				for _, item := range box.GetItems() {
+					require.Contains(t, item.GetAllowedRotations(), item.GetRotationType(),
+						"Item %s placed in a forbidden orientation", item.GetID())
+					require.InDelta(t, item.GetHeight(), item.GetDimension()[boxpacker3.HeightAxis], 0.0001)
+				}
+			}
+		})
+	}
+}
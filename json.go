@@ -0,0 +1,346 @@
+package boxpacker3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONSchemaVersion is the version of the JSON representation of results, boxes and items.
+// It is written to every marshalled Result and checked when a Result is unmarshalled.
+const JSONSchemaVersion = 1
+
+// ErrUnsupportedSchemaVersion is returned when unmarshalling a Result written by a newer schema.
+var ErrUnsupportedSchemaVersion = errors.New("boxpacker3: unsupported JSON schema version")
+
+// ErrUnknownRotationType is returned when unmarshalling an unknown rotation name.
+var ErrUnknownRotationType = errors.New("boxpacker3: unknown rotation type")
+
+type itemJSON struct {
+	ID        string       `json:"id"`
+	Width     float64      `json:"width"`
+	Height    float64      `json:"height"`
+	Depth     float64      `json:"depth"`
+	Weight    float64      `json:"weight"`
+	Position  Pivot        `json:"position"`
+	Rotation  RotationType `json:"rotation"`
+	Dimension Dimension    `json:"dimension"`
+
+	AllowedRotations []RotationType `json:"allowedRotations,omitempty"`
+	MaxLoad          *float64       `json:"maxLoad,omitempty"`
+	UnfitReason      UnfitReason    `json:"unfitReason,omitempty"`
+	Line             *itemLineJSON  `json:"line,omitempty"`
+	Unit             int            `json:"unit,omitempty"`
+}
+
+type itemLineJSON struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+type boxTypeJSON struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+type boxJSON struct {
+	ID         string       `json:"id"`
+	Width      float64      `json:"width"`
+	Height     float64      `json:"height"`
+	Depth      float64      `json:"depth"`
+	MaxWeight  float64      `json:"maxWeight"`
+	Cost       float64      `json:"cost,omitempty"`
+	MinSupport float64      `json:"minSupport,omitempty"`
+	Type       *boxTypeJSON `json:"type,omitempty"`
+	Items      []*Item      `json:"items"`
+	Cuts       []Cut        `json:"cuts,omitempty"`
+	Strip      bool         `json:"strip,omitempty"`
+	UsedLength float64      `json:"usedLength,omitempty"`
+	Pallet     bool         `json:"pallet,omitempty"`
+	Overhang   float64      `json:"overhang,omitempty"`
+
+	Axles                 []Axle         `json:"axles,omitempty"`
+	CenterOfGravityLimits *cogLimitsJSON `json:"centerOfGravityLimits,omitempty"`
+	CenterOfGravity       *Pivot         `json:"centerOfGravity,omitempty"`
+	AxleLoads             []float64      `json:"axleLoads,omitempty"`
+
+	ItemsVolume     float64        `json:"itemsVolume"`
+	ItemsWeight     float64        `json:"itemsWeight"`
+	ItemCounts      map[string]int `json:"itemCounts,omitempty"`
+	RemainingVolume float64        `json:"remainingVolume"`
+	FillRate        float64        `json:"fillRate"`
+}
+
+type cogLimitsJSON struct {
+	Min Pivot `json:"min"`
+	Max Pivot `json:"max"`
+}
+
+type resultJSON struct {
+	Version    int      `json:"version"`
+	Boxes      []*Box   `json:"boxes"`
+	UnfitItems []*Item  `json:"unfitItems"`
+	Optimal    bool     `json:"optimal,omitempty"`
+	Unfinished []string `json:"unfinished,omitempty"`
+}
+
+// MarshalText encodes the rotation as its axis order, e.g. "WHD".
+func (rt RotationType) MarshalText() ([]byte, error) {
+	if rt < RotationTypeWhd || rt > RotationTypeWdh {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownRotationType, int(rt))
+	}
+
+	return []byte(rt.String()), nil
+}
+
+// UnmarshalText decodes a rotation from its axis order, e.g. "WHD".
+func (rt *RotationType) UnmarshalText(text []byte) error {
+	for r := RotationTypeWhd; r <= RotationTypeWdh; r++ {
+		if r.String() == string(text) {
+			*rt = r
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownRotationType, string(text))
+}
+
+// MarshalJSON encodes the item with its original dimensions, placement and constraints.
+func (i *Item) MarshalJSON() ([]byte, error) {
+	v := itemJSON{
+		ID:        i.id,
+		Width:     i.whd[WidthAxis],
+		Height:    i.whd[HeightAxis],
+		Depth:     i.whd[DepthAxis],
+		Weight:    i.weight,
+		Position:  i.position,
+		Rotation:  i.rotationType,
+		Dimension: i.GetDimension(),
+
+		UnfitReason: i.unfitReason,
+	}
+
+	if i.rotations != 0 {
+		v.AllowedRotations = i.GetAllowedRotations()
+	}
+
+	if i.loadLimited {
+		v.MaxLoad = &i.maxLoad
+	}
+
+	if i.line != nil {
+		v.Line = &itemLineJSON{ID: i.line.GetID(), Quantity: i.line.quantity}
+		v.Unit = i.unit
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes an item written by MarshalJSON. The rotated dimension is derived, not read.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var v itemJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var opts []ItemOption
+
+	if len(v.AllowedRotations) > 0 {
+		opts = append(opts, WithAllowedRotations(v.AllowedRotations...))
+	}
+
+	if v.MaxLoad != nil {
+		opts = append(opts, WithMaxLoad(*v.MaxLoad))
+	}
+
+	item := NewItem(v.ID, v.Width, v.Height, v.Depth, v.Weight, opts...)
+	item.position = v.Position
+	item.rotationType = v.Rotation
+	item.unfitReason = v.UnfitReason
+
+	if v.Line != nil {
+		item.line = NewItemLine(item, v.Line.Quantity)
+		item.line.prototype.id = v.Line.ID
+		item.unit = v.Unit
+	}
+
+	*i = *item
+
+	return nil
+}
+
+// MarshalJSON encodes the box with its items and fill metrics.
+func (b *Box) MarshalJSON() ([]byte, error) {
+	v := boxJSON{
+		ID:              b.id,
+		Width:           b.width,
+		Height:          b.height,
+		Depth:           b.depth,
+		MaxWeight:       b.maxWeight,
+		Cost:            b.cost,
+		MinSupport:      b.minSupport,
+		Items:           b.GetItems(),
+		Cuts:            b.cuts,
+		Strip:           b.strip,
+		Pallet:          b.pallet,
+		Overhang:        b.overhang,
+		ItemsVolume:     b.itemsVolume,
+		ItemsWeight:     b.itemsWeight,
+		ItemCounts:      b.GetItemCounts(),
+		RemainingVolume: b.GetRemainingVolume(),
+		FillRate:        b.GetFillRate(),
+	}
+
+	if b.boxType != nil {
+		v.Type = &boxTypeJSON{ID: b.boxType.GetID(), Quantity: b.boxType.quantity}
+	}
+
+	if b.strip {
+		v.UsedLength = b.GetUsedLength()
+	}
+
+	if len(b.axles) > 0 || b.cogLimits != nil {
+		cog := b.GetCenterOfGravity()
+		v.Axles = b.axles
+		v.CenterOfGravity = &cog
+		v.AxleLoads = b.GetAxleLoads()
+	}
+
+	if b.cogLimits != nil {
+		v.CenterOfGravityLimits = &cogLimitsJSON{Min: b.cogLimits[0], Max: b.cogLimits[1]}
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a box written by MarshalJSON.
+// Items are restored at their recorded positions without re-running placement checks;
+// fill metrics are recalculated from the items.
+func (b *Box) UnmarshalJSON(data []byte) error {
+	var v boxJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	box := NewBox(v.ID, v.Width, v.Height, v.Depth, v.MaxWeight, WithCost(v.Cost), WithMinSupport(v.MinSupport))
+
+	if len(v.Axles) == 2 { //nolint:mnd // front and rear axle
+		WithAxles(v.Axles[0], v.Axles[1])(box)
+	}
+
+	if v.CenterOfGravityLimits != nil {
+		WithCenterOfGravityLimits(v.CenterOfGravityLimits.Min, v.CenterOfGravityLimits.Max)(box)
+	}
+
+	if v.Type != nil {
+		box.boxType = NewBoxType(box, v.Type.Quantity)
+		box.boxType.prototype.id = v.Type.ID
+	}
+
+	for _, item := range v.Items {
+		if item != nil {
+			box.insert(item)
+		}
+	}
+
+	box.cuts = v.Cuts
+	box.strip = v.Strip
+	box.pallet = v.Pallet
+	// The width and depth of a pallet are written with the overhang on both sides included
+	// (see WithOverhang), so they are restored as is; only the overhang itself is set here.
+	box.overhang = v.Overhang
+	box.addDeckPoint()
+
+	*b = *box
+
+	return nil
+}
+
+// MarshalJSON encodes the result together with the schema version.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	v := resultJSON{
+		Version:    JSONSchemaVersion,
+		Boxes:      r.Boxes,
+		UnfitItems: r.UnfitItems,
+		Optimal:    r.Optimal,
+		Unfinished: r.Unfinished,
+	}
+
+	if v.Boxes == nil {
+		v.Boxes = []*Box{}
+	}
+
+	if v.UnfitItems == nil {
+		v.UnfitItems = []*Item{}
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a result written by MarshalJSON.
+// Boxes that were opened from the same box type share a single BoxType again,
+// and units of the same order line share a single ItemLine.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var v resultJSON
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Version > JSONSchemaVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, v.Version)
+	}
+
+	types := make(map[boxTypeJSON]*BoxType)
+
+	for _, b := range v.Boxes {
+		if b == nil || b.boxType == nil {
+			continue
+		}
+
+		key := boxTypeJSON{ID: b.boxType.GetID(), Quantity: b.boxType.quantity}
+		if bt, ok := types[key]; ok {
+			b.boxType = bt
+		} else {
+			types[key] = b.boxType
+		}
+	}
+
+	lines := make(map[itemLineJSON]*ItemLine)
+	shareLine := func(it *Item) {
+		if it == nil || it.line == nil {
+			return
+		}
+
+		key := itemLineJSON{ID: it.line.GetID(), Quantity: it.line.quantity}
+		if l, ok := lines[key]; ok {
+			it.line = l
+		} else {
+			lines[key] = it.line
+		}
+	}
+
+	for _, b := range v.Boxes {
+		if b != nil {
+			for _, it := range b.items {
+				shareLine(it)
+			}
+		}
+	}
+
+	for _, it := range v.UnfitItems {
+		shareLine(it)
+	}
+
+	r.Boxes = make(boxSlice, 0, len(v.Boxes))
+	r.Boxes = append(r.Boxes, v.Boxes...)
+	r.UnfitItems = make(itemSlice, 0, len(v.UnfitItems))
+	r.UnfitItems = append(r.UnfitItems, v.UnfitItems...)
+	r.Optimal = v.Optimal
+	r.Unfinished = v.Unfinished
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestJSON_Item tests the JSON representation of a placed item.
+func TestJSON_Item(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 30, 20, 10, 1000)
+	item := boxpacker3.NewItem("liquid", 10, 20, 30, 2, boxpacker3.WithThisSideUp(), boxpacker3.WithMaxLoad(5))
+	require.True(t, box.PutItem(item, boxpacker3.Pivot{}))
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": "liquid",
+		"width": 10, "height": 20, "depth": 30,
+		"weight": 2,
+		"position": [0, 0, 0],
+		"rotation": "DHW",
+		"dimension": [30, 20, 10],
+		"allowedRotations": ["WHD", "DHW"],
+		"maxLoad": 5
+	}`, string(data))
+
+	var decoded boxpacker3.Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, "liquid", decoded.GetID())
+	require.Equal(t, boxpacker3.RotationTypeDhw, decoded.GetRotationType())
+	require.Equal(t, item.GetDimension(), decoded.GetDimension())
+	require.Equal(t, item.GetAllowedRotations(), decoded.GetAllowedRotations())
+	require.InDelta(t, 5.0, decoded.GetMaxLoad(), 0.0001)
+}
+
+// TestJSON_Item_UnknownRotation tests that unknown rotations are rejected.
+func TestJSON_Item_UnknownRotation(t *testing.T) {
+	t.Parallel()
+
+	var item boxpacker3.Item
+
+	err := json.Unmarshal([]byte(`{"id":"x","width":1,"height":1,"depth":1,"rotation":"XYZ"}`), &item)
+	require.ErrorIs(t, err, boxpacker3.ErrUnknownRotationType)
+
+	_, err = json.Marshal(boxpacker3.RotationType(42))
+	require.ErrorIs(t, err, boxpacker3.ErrUnknownRotationType)
+}
+
+// TestJSON_Box tests the JSON representation of a box with fill metrics.
+func TestJSON_Box(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 10, 10, 100, boxpacker3.WithCost(1.5))
+	require.True(t, box.PutItem(boxpacker3.NewItem("item", 5, 10, 10, 7), boxpacker3.Pivot{}))
+
+	data, err := json.Marshal(box)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.InDelta(t, 500.0, raw["itemsVolume"], 0.0001)
+	require.InDelta(t, 7.0, raw["itemsWeight"], 0.0001)
+	require.InDelta(t, 500.0, raw["remainingVolume"], 0.0001)
+	require.InDelta(t, 0.5, raw["fillRate"], 0.0001)
+	require.InDelta(t, 1.5, raw["cost"], 0.0001)
+	require.NotContains(t, raw, "type")
+
+	var decoded boxpacker3.Box
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, "box", decoded.GetID())
+	require.InDelta(t, 1.5, decoded.GetCost(), 0.0001)
+	require.InDelta(t, 0.5, decoded.GetFillRate(), 0.0001)
+	require.Len(t, decoded.GetItems(), 1)
+}
+
+// TestJSON_Result_RoundTrip tests that a packing result survives a JSON round trip.
+func TestJSON_Result_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	carton := boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 100, 100, 100, 10000), boxpacker3.UnlimitedQuantity)
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("item-1", 60, 60, 60, 10),
+		boxpacker3.NewItem("item-2", 60, 60, 60, 10),
+		boxpacker3.NewItem("huge", 600, 600, 600, 10),
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxpacker3.BoxesFromTypes(carton), items)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.InDelta(t, float64(boxpacker3.JSONSchemaVersion), raw["version"], 0.0001)
+
+	var decoded boxpacker3.Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded.Boxes, 2)
+	require.Len(t, decoded.UnfitItems, 1)
+	require.Equal(t, "huge", decoded.UnfitItems[0].GetID())
+
+	require.NotNil(t, decoded.Boxes[0].GetBoxType())
+	require.Same(t, decoded.Boxes[0].GetBoxType(), decoded.Boxes[1].GetBoxType(), "Boxes of one type share it after decoding")
+	require.Equal(t, "carton", decoded.Boxes[0].GetBoxType().GetID())
+	require.True(t, decoded.Boxes[0].GetBoxType().IsUnlimited())
+
+	for i, box := range decoded.Boxes {
+		original := result.Boxes[i].GetItems()
+		for j, item := range box.GetItems() {
+			require.Equal(t, original[j].GetID(), item.GetID())
+			require.Equal(t, original[j].GetPosition(), item.GetPosition())
+			require.Equal(t, original[j].GetDimension(), item.GetDimension())
+		}
+	}
+
+	again, err := json.Marshal(&decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(again))
+}
+
+// TestJSON_Result_Version tests schema version handling.
+func TestJSON_Result_Version(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(&boxpacker3.Result{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version": 1, "boxes": [], "unfitItems": []}`, string(data))
+
+	var res boxpacker3.Result
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"version": 99}`), &res), boxpacker3.ErrUnsupportedSchemaVersion)
+}
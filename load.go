@@ -0,0 +1,101 @@
+package boxpacker3
+
+import (
+	"math"
+	"sort"
+)
+
+// loadTolerance absorbs floating point noise when comparing loads against limits.
+const loadTolerance = 1e-9
+
+// WithMaxLoad limits the total weight that may rest on top of the item,
+// directly or through other items stacked on it.
+func WithMaxLoad(weight float64) ItemOption {
+	return func(i *Item) {
+		i.maxLoad = max(weight, 0)
+		i.loadLimited = true
+	}
+}
+
+// WithFragile forbids placing anything on top of the item.
+func WithFragile() ItemOption {
+	return WithMaxLoad(0)
+}
+
+// GetMaxLoad returns the maximum weight that may rest on the item, or +Inf when unlimited.
+func (i *Item) GetMaxLoad() float64 {
+	if !i.loadLimited {
+		return math.Inf(1)
+	}
+
+	return i.maxLoad
+}
+
+// GetLoad returns the total weight resting on the item inside the box.
+//
+// An item standing on several items is counted in full against each of them,
+// which keeps the check on the safe side.
+func (b *Box) GetLoad(item *Item) float64 {
+	return itemLoads(b.items)[item]
+}
+
+// canCarry reports whether placing the item keeps every load-limited item in the box within its limit.
+func (b *Box) canCarry(item *Item) bool {
+	limited := item.loadLimited
+
+	for _, it := range b.items {
+		if limited {
+			break
+		}
+
+		limited = it != nil && it.loadLimited
+	}
+
+	if !limited {
+		return true
+	}
+
+	items := append(b.GetItems(), item)
+	loads := itemLoads(items)
+
+	for _, it := range items {
+		if it != nil && it.loadLimited && loads[it] > it.maxLoad+loadTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// itemLoads computes the weight resting on each item, directly or through the items stacked on it.
+func itemLoads(items []*Item) map[*Item]float64 {
+	sorted := make([]*Item, 0, len(items))
+
+	for _, it := range items {
+		if it != nil {
+			sorted = append(sorted, it)
+		}
+	}
+
+	// Items higher up are processed first, so their own loads are known
+	// by the time the items below them are evaluated.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].position[HeightAxis] > sorted[j].position[HeightAxis]
+	})
+
+	loads := make(map[*Item]float64, len(sorted))
+
+	for i, lower := range sorted {
+		var load float64
+
+		for _, upper := range sorted[:i] {
+			if contactArea(upper, lower) > 0 {
+				load += upper.weight + loads[upper]
+			}
+		}
+
+		loads[lower] = load
+	}
+
+	return loads
+}
@@ -0,0 +1,100 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestItem_MaxLoad tests the max load accessors and defaults.
+func TestItem_MaxLoad(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, math.IsInf(boxpacker3.NewItem("any", 1, 1, 1, 1).GetMaxLoad(), 1))
+	require.InDelta(t, 5.0, boxpacker3.NewItem("limited", 1, 1, 1, 1, boxpacker3.WithMaxLoad(5)).GetMaxLoad(), 0.0001)
+	require.InDelta(t, 0.0, boxpacker3.NewItem("glass", 1, 1, 1, 1, boxpacker3.WithFragile()).GetMaxLoad(), 0.0001)
+}
+
+// TestBox_PutItem_Fragile tests that nothing can be stacked on a fragile item.
+func TestBox_PutItem_Fragile(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 100, 10, 1000)
+	glass := boxpacker3.NewItem("glass", 10, 10, 10, 1, boxpacker3.WithFragile())
+	require.True(t, box.PutItem(glass, boxpacker3.Pivot{}))
+
+	tool := boxpacker3.NewItem("tool", 10, 10, 10, 20)
+	require.False(t, box.PutItem(tool, boxpacker3.Pivot{0, 10, 0}), "Tool must not crush the glass")
+	require.InDelta(t, 0.0, box.GetLoad(glass), 0.0001)
+}
+
+// TestBox_PutItem_MaxLoad_Transitive tests that the weight of the whole stack counts against the bottom item.
+func TestBox_PutItem_MaxLoad_Transitive(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 100, 10, 1000)
+	bottom := boxpacker3.NewItem("bottom", 10, 10, 10, 1, boxpacker3.WithMaxLoad(5))
+	middle := boxpacker3.NewItem("middle", 10, 10, 10, 3)
+	top := boxpacker3.NewItem("top", 10, 10, 10, 3)
+	light := boxpacker3.NewItem("light", 10, 10, 10, 2)
+
+	require.True(t, box.PutItem(bottom, boxpacker3.Pivot{}))
+	require.True(t, box.PutItem(middle, boxpacker3.Pivot{0, 10, 0}))
+	require.False(t, box.PutItem(top, boxpacker3.Pivot{0, 20, 0}), "Stack of 6 exceeds the limit of 5")
+	require.True(t, box.PutItem(light, boxpacker3.Pivot{0, 20, 0}))
+
+	require.InDelta(t, 5.0, box.GetLoad(bottom), 0.0001)
+	require.InDelta(t, 2.0, box.GetLoad(middle), 0.0001)
+	require.InDelta(t, 0.0, box.GetLoad(light), 0.0001)
+}
+
+// TestPacker_MaxLoad_AllStrategies tests that no strategy produces crushing stacks.
+func TestPacker_MaxLoad_AllStrategies(t *testing.T) {
+	t.Parallel()
+
+	strategies := []boxpacker3.PackingStrategy{
+		boxpacker3.StrategyMinimizeBoxes,
+		boxpacker3.StrategyGreedy,
+		boxpacker3.StrategyBestFit,
+		boxpacker3.StrategyBestFitDecreasing,
+		boxpacker3.StrategyNextFit,
+		boxpacker3.StrategyWorstFit,
+		boxpacker3.StrategyAlmostWorstFit,
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategyName(strategy), func(t *testing.T) {
+			t.Parallel()
+
+			boxes := []*boxpacker3.Box{
+				boxpacker3.NewBox("box-1", 40, 40, 40, 10000),
+				boxpacker3.NewBox("box-2", 40, 40, 40, 10000),
+			}
+
+			items := make([]*boxpacker3.Item, 0, 16)
+			for i := range 8 {
+				items = append(items,
+					boxpacker3.NewItem("glass-"+strconv.Itoa(i), 20, 20, 20, 1, boxpacker3.WithMaxLoad(1)),
+					boxpacker3.NewItem("tool-"+strconv.Itoa(i), 20, 20, 20, 20),
+				)
+			}
+
+			packer := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy))
+			result, err := packer.PackCtx(context.Background(), boxes, items)
+			require.NoError(t, err)
+			validatePackingInvariants(t, result)
+
+			for _, box := range result.Boxes {
+				for _, item := range box.GetItems() {
+					require.LessOrEqual(t, box.GetLoad(item), item.GetMaxLoad(),
+						"Item %s in box %s is crushed", item.GetID(), box.GetID())
+				}
+			}
+		})
+	}
+}
@@ -9,46 +9,76 @@ type PackerOption func(*Packer)
 
 // WithStrategy sets the packing strategy using the legacy enum constants.
 // This ensures backward compatibility with existing codebases.
+// Inputs where every box and item is 2D are still packed by the MaxRectsStrategy,
+// or by the SkylineStrategy when one of the boxes is a strip.
 func WithStrategy(strategy PackingStrategy) PackerOption {
 	return func(p *Packer) {
-		switch strategy {
-		case StrategyMinimizeBoxes:
-			p.algorithm = NewMinimizeBoxesStrategy()
-		case StrategyGreedy:
-			p.algorithm = NewGreedyStrategy()
-		case StrategyBestFit:
-			p.algorithm = NewBestFitStrategy()
-		case StrategyBestFitDecreasing:
-			p.algorithm = NewBestFitDecreasingStrategy()
-		case StrategyNextFit:
-			p.algorithm = NewNextFitStrategy()
-		case StrategyWorstFit:
-			p.algorithm = NewWorstFitStrategy()
-		case StrategyAlmostWorstFit:
-			p.algorithm = NewAlmostWorstFitStrategy()
-		default:
-			p.algorithm = NewMinimizeBoxesStrategy()
-		}
+		p.algorithm = NewAlgorithm(strategy)
+	}
+}
+
+// NewAlgorithm returns the built-in PackingAlgorithm for the strategy.
+// Unknown strategies fall back to MinimizeBoxes.
+//
+//nolint:ireturn
+func NewAlgorithm(strategy PackingStrategy) PackingAlgorithm {
+	switch strategy {
+	case StrategyMinimizeBoxes:
+		return NewMinimizeBoxesStrategy()
+	case StrategyGreedy:
+		return NewGreedyStrategy()
+	case StrategyBestFit:
+		return NewBestFitStrategy()
+	case StrategyBestFitDecreasing:
+		return NewBestFitDecreasingStrategy()
+	case StrategyNextFit:
+		return NewNextFitStrategy()
+	case StrategyWorstFit:
+		return NewWorstFitStrategy()
+	case StrategyAlmostWorstFit:
+		return NewAlmostWorstFitStrategy()
+	default:
+		return NewMinimizeBoxesStrategy()
 	}
 }
 
 // WithAlgorithm sets a specific packing algorithm instance.
 // This allows for custom implementations or the use of the ParallelStrategy runner.
+// The algorithm is used for 2D inputs as well, instead of the MaxRectsStrategy or SkylineStrategy.
 func WithAlgorithm(algo PackingAlgorithm) PackerOption {
 	return func(p *Packer) {
 		p.algorithm = algo
+		p.customAlgorithm = true
+	}
+}
+
+// WithStability enables stability mode (see WithMinSupport) for every box
+// that does not configure its own minimum support ratio.
+func WithStability(minSupport float64) PackerOption {
+	return func(p *Packer) {
+		p.minSupport = minSupport
 	}
 }
 
 // Packer packs items into boxes using a configurable algorithm.
 type Packer struct {
-	algorithm PackingAlgorithm
+	algorithm       PackingAlgorithm
+	customAlgorithm bool
+	minSupport      float64
+	validateInput   bool
 }
 
 // Result represents the result of packing items into boxes.
 type Result struct {
 	UnfitItems itemSlice
 	Boxes      boxSlice
+
+	// Optimal is set by algorithms that prove their result cannot be improved (see BranchAndBoundStrategy).
+	Optimal bool
+
+	// Unfinished lists the algorithms of a best-effort ParallelStrategy that did not finish
+	// before the context was done (see WithBestEffort).
+	Unfinished []string
 }
 
 // NewPacker creates a new Packer.
@@ -67,16 +97,47 @@ func NewPacker(opts ...PackerOption) *Packer {
 
 // PackCtx packs items into boxes with context support for cancellation.
 // It delegates the actual logic to the configured PackingAlgorithm.
+// With WithInputValidation, invalid input is reported by an error from ValidateInput.
 func (p *Packer) PackCtx(ctx context.Context, inputBoxes []*Box, inputItems []*Item) (*Result, error) {
+	if p.validateInput {
+		if err := ValidateInput(inputBoxes, inputItems); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.pack(ctx, inputBoxes, CopySlicePtr(inputItems))
+}
+
+// pack runs the algorithm on copies of the boxes. The items are owned by the caller of pack.
+func (p *Packer) pack(ctx context.Context, inputBoxes []*Box, items []*Item) (*Result, error) {
 	if inputBoxes == nil {
 		inputBoxes = []*Box{}
 	}
 
-	if inputItems == nil {
-		inputItems = []*Item{}
+	if items == nil {
+		items = []*Item{}
+	}
+
+	boxes := CopySlicePtr(inputBoxes)
+
+	if p.minSupport > 0 {
+		for _, b := range boxes {
+			if b != nil && b.minSupport == 0 {
+				WithMinSupport(p.minSupport)(b)
+			}
+		}
+	}
+
+	// 2D inputs are packed by the dedicated engines unless an algorithm was set explicitly.
+	if !p.customAlgorithm && all2D(boxes, items) {
+		if hasStrip(boxes) {
+			return NewSkylineStrategy().Pack(ctx, boxes, items)
+		}
+
+		return NewMaxRectsStrategy().Pack(ctx, boxes, items)
 	}
 
-	return p.algorithm.Pack(ctx, CopySlicePtr(inputBoxes), CopySlicePtr(inputItems))
+	return p.algorithm.Pack(ctx, boxes, items)
 }
 
 // Pack packs items into boxes.
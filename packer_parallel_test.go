@@ -3,6 +3,7 @@ package boxpacker3_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -128,3 +129,109 @@ func makeMockItems(n int) []*boxpacker3.Item {
 
 	return items
 }
+
+// SlowAlgo blocks until the context is done; with ignoreContext it sleeps for the given delay instead.
+type SlowAlgo struct {
+	name          string
+	delay         time.Duration
+	ignoreContext bool
+}
+
+func (m *SlowAlgo) Name() string { return m.name }
+
+// Pack implements PackingAlgorithm.
+func (m *SlowAlgo) Pack(ctx context.Context, _ []*boxpacker3.Box, _ []*boxpacker3.Item) (*boxpacker3.Result, error) {
+	if m.ignoreContext {
+		time.Sleep(m.delay)
+
+		return &boxpacker3.Result{}, nil
+	}
+
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// TestParallel_BestEffort verifies that a best-effort runner returns the best finished result
+// on deadline and names the algorithms that did not finish.
+func TestParallel_BestEffort(t *testing.T) {
+	t.Parallel()
+
+	fast := &MockAlgo{
+		name: "Fast",
+		res: &boxpacker3.Result{
+			Boxes:      makeMockBoxes(2),
+			UnfitItems: makeMockItems(0),
+		},
+	}
+	waiting := &SlowAlgo{name: "Waiting"}
+	stuck := &SlowAlgo{name: "Stuck", delay: 2 * time.Second, ignoreContext: true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res, err := boxpacker3.NewParallelStrategy(
+		boxpacker3.WithAlgorithms(fast, waiting, stuck),
+		boxpacker3.WithBestEffort(),
+	).Pack(ctx, nil, nil)
+
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), time.Second, "Stuck algorithms should not delay the answer")
+	require.Len(t, res.Boxes, 2)
+	require.Equal(t, []string{"Waiting", "Stuck"}, res.Unfinished)
+	require.Empty(t, fast.res.Unfinished, "The result of the algorithm itself should be left untouched")
+
+	// Without any finished algorithm the deadline error is still returned.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = boxpacker3.NewParallelStrategy(
+		boxpacker3.WithAlgorithms(waiting),
+		boxpacker3.WithBestEffort(),
+	).Pack(ctx, nil, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Without the option the finished results are discarded.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = boxpacker3.NewParallelStrategy(
+		boxpacker3.WithAlgorithms(fast, waiting),
+	).Pack(ctx, nil, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestParallel_BestEffort_Buffered verifies that results sent before the deadline are never reported as unfinished,
+// even when the deadline and the result are ready at the same time.
+func TestParallel_BestEffort_Buffered(t *testing.T) {
+	t.Parallel()
+
+	for range 20 {
+		first := &MockAlgo{name: "First", res: &boxpacker3.Result{Boxes: makeMockBoxes(2)}}
+		second := &SlowAlgo{name: "Second", delay: 5 * time.Millisecond, ignoreContext: true}
+
+		// The runner is busy comparing the first result while the second one is buffered and the deadline passes.
+		slowGoal := func(candidate, best *boxpacker3.Result) bool {
+			if best == nil {
+				time.Sleep(30 * time.Millisecond)
+			}
+
+			return boxpacker3.MinimizeBoxesGoal(candidate, best)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Millisecond)
+
+		res, err := boxpacker3.NewParallelStrategy(
+			boxpacker3.WithAlgorithms(first, second, &SlowAlgo{name: "Waiting"}),
+			boxpacker3.WithGoal(slowGoal),
+			boxpacker3.WithBestEffort(),
+		).Pack(ctx, nil, nil)
+
+		cancel()
+
+		require.NoError(t, err)
+		require.Empty(t, res.Boxes, "The empty result of Second wins")
+		require.Equal(t, []string{"Waiting"}, res.Unfinished)
+	}
+}
@@ -0,0 +1,100 @@
+package boxpacker3
+
+// NewPallet creates a pallet with the given footprint (width × depth), maximum load height and maximum weight.
+//
+// A pallet is a Box whose height is the maximum height of the load above the deck, and GetUsedLength
+// reports the height of the load. Use WithOverhang to let items stick out of the footprint,
+// and the LayerStrategy to build it up in layers.
+func NewPallet(id string, width, depth, maxHeight, maxWeight float64, opts ...BoxOption) *Box {
+	box := NewBox(id, width, maxHeight, depth, maxWeight)
+	box.pallet = true
+
+	// The options see a pallet, so WithOverhang applies.
+	for _, opt := range opts {
+		opt(box)
+	}
+
+	return box
+}
+
+// WithOverhang lets items stick out of each side of the footprint of a pallet by up to overhang.
+//
+// The box grows by twice the overhang along the width and the depth, with the footprint in its middle,
+// so positions stay non-negative: the footprint starts at (overhang, 0, overhang). An item may only stick out
+// of the footprint: the centre of its base has to be over the deck, and on the floor only the part of the base
+// over the deck counts as supported (see WithMinSupport).
+//
+// GetWidth, GetDepth and GetVolume, and so the fill rate of a Result, include the overhang, as do the
+// "width" and "depth" written to JSON; GetFootprint returns the deck alone. The option has no effect
+// on boxes that are not pallets.
+func WithOverhang(overhang float64) BoxOption {
+	return func(b *Box) {
+		if !b.pallet {
+			return
+		}
+
+		overhang = max(overhang, 0)
+		grow := 2 * (overhang - b.overhang) //nolint:mnd // one overhang on each side
+
+		b.overhang = overhang
+		b.width += grow
+		b.depth += grow
+		b.volume = b.width * b.height * b.depth
+		b.maxLength = max(b.width, b.height, b.depth)
+		b.addDeckPoint()
+	}
+}
+
+// IsPallet reports whether the box is a pallet created by NewPallet.
+func (b *Box) IsPallet() bool {
+	return b.pallet
+}
+
+// GetOverhang returns how far items may stick out of each side of the footprint, 0 by default.
+func (b *Box) GetOverhang() float64 {
+	return b.overhang
+}
+
+// GetFootprint returns the width and depth of the pallet deck, i.e. the box without the overhang.
+func (b *Box) GetFootprint() (float64, float64) {
+	return b.width - 2*b.overhang, b.depth - 2*b.overhang //nolint:mnd // one overhang on each side
+}
+
+// addDeckPoint adds the corner of the deck to the extreme points, so a load may start on the footprint
+// rather than in the overhang.
+func (b *Box) addDeckPoint() {
+	if b.overhang > 0 {
+		b.addExtremePoint(Pivot{b.overhang, 0, b.overhang})
+	}
+}
+
+// overDeck reports whether the centre of the item's base is over the deck, so the item sticks out
+// of the footprint by at most half of its size and never stands next to it. Boxes without an overhang have no limit.
+func (b *Box) overDeck(item *Item) bool {
+	if b.overhang <= 0 {
+		return true
+	}
+
+	dim := item.GetDimension()
+	width, depth := b.GetFootprint()
+	x := item.position[WidthAxis] + dim[WidthAxis]/2 //nolint:mnd // centre of the base
+	z := item.position[DepthAxis] + dim[DepthAxis]/2 //nolint:mnd // centre of the base
+
+	return x >= b.overhang-pointTolerance && x <= b.overhang+width+pointTolerance &&
+		z >= b.overhang-pointTolerance && z <= b.overhang+depth+pointTolerance
+}
+
+// deckRatio returns the share of the item's base over the deck, 1 for boxes without an overhang.
+func (b *Box) deckRatio(item *Item) float64 {
+	dim := item.GetDimension()
+	baseArea := dim[WidthAxis] * dim[DepthAxis]
+
+	if b.overhang <= 0 || baseArea <= 0 {
+		return 1
+	}
+
+	width, depth := b.GetFootprint()
+
+	return overlap(item.position[WidthAxis], dim[WidthAxis], b.overhang, width) *
+		overlap(item.position[DepthAxis], dim[DepthAxis], b.overhang, depth) / baseArea
+}
@@ -0,0 +1,47 @@
+package boxpacker3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestParsePackingStrategy tests that every strategy can be parsed back from its name.
+func TestParsePackingStrategy(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		parsed, err := boxpacker3.ParsePackingStrategy(strategy.String())
+		require.NoError(t, err)
+		require.Equal(t, strategy, parsed)
+		require.Equal(t, strategy.String(), boxpacker3.NewAlgorithm(strategy).Name())
+	}
+
+	parsed, err := boxpacker3.ParsePackingStrategy("bestfitdecreasing")
+	require.NoError(t, err)
+	require.Equal(t, boxpacker3.StrategyBestFitDecreasing, parsed)
+
+	_, err = boxpacker3.ParsePackingStrategy("Magic")
+	require.ErrorIs(t, err, boxpacker3.ErrUnknownStrategy)
+	require.Equal(t, "Unknown(42)", boxpacker3.PackingStrategy(42).String())
+}
+
+// TestParseGoal tests that every built-in goal name can be parsed.
+func TestParseGoal(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range boxpacker3.GoalNames() {
+		goal, err := boxpacker3.ParseGoal(name)
+		require.NoError(t, err, name)
+		require.NotNil(t, goal)
+		require.True(t, goal(&boxpacker3.Result{}, nil))
+	}
+
+	_, err := boxpacker3.ParseGoal("tightestpacking")
+	require.NoError(t, err)
+
+	_, err = boxpacker3.ParseGoal("Cheapest")
+	require.ErrorIs(t, err, boxpacker3.ErrUnknownGoal)
+}
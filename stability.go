@@ -0,0 +1,92 @@
+package boxpacker3
+
+import "math"
+
+// supportTolerance is the maximum gap between the top of an item and the base of
+// the item above it for the lower one to still count as a support.
+const supportTolerance = 1e-6
+
+// WithMinSupport enables stability mode for the box.
+//
+// Every item placed above the floor must have at least ratio (0..1] of its base area
+// resting on the top faces of items directly below it. The vertical axis is HeightAxis.
+// A ratio of 0 disables the check.
+func WithMinSupport(ratio float64) BoxOption {
+	return func(b *Box) {
+		b.minSupport = min(max(ratio, 0), 1)
+	}
+}
+
+// GetMinSupport returns the minimum supported base ratio required by the box, 0 when disabled.
+func (b *Box) GetMinSupport() float64 {
+	return b.minSupport
+}
+
+// GetSupportRatio returns the share of the item's base area that rests on the box floor
+// or on the top faces of other items in the box. The item's current position and rotation are used.
+// On a pallet with an overhang, the floor is the deck only.
+func (b *Box) GetSupportRatio(item *Item) float64 {
+	if item == nil {
+		return 0
+	}
+
+	dim := item.GetDimension()
+
+	if item.position[HeightAxis] <= supportTolerance {
+		return b.deckRatio(item)
+	}
+
+	baseArea := dim[WidthAxis] * dim[DepthAxis]
+	if baseArea <= 0 {
+		return 1
+	}
+
+	var supported float64
+
+	for _, below := range b.items {
+		if below != nil && below != item {
+			supported += contactArea(item, below)
+		}
+	}
+
+	return min(supported/baseArea, 1)
+}
+
+// IsStable reports whether every item in the box satisfies the box's minimum support ratio.
+// Boxes without stability mode are always stable.
+func (b *Box) IsStable() bool {
+	for _, item := range b.items {
+		if !b.isSupported(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *Box) isSupported(item *Item) bool {
+	if b.minSupport <= 0 {
+		return true
+	}
+
+	return b.GetSupportRatio(item)+supportTolerance >= b.minSupport
+}
+
+// contactArea returns the area of the base of upper that rests on the top face of lower.
+func contactArea(upper, lower *Item) float64 {
+	upperDim := upper.GetDimension()
+	lowerDim := lower.GetDimension()
+	top := lower.position[HeightAxis] + lowerDim[HeightAxis]
+
+	if math.Abs(top-upper.position[HeightAxis]) > supportTolerance {
+		return 0
+	}
+
+	return overlap(upper.position[WidthAxis], upperDim[WidthAxis], lower.position[WidthAxis], lowerDim[WidthAxis]) *
+		overlap(upper.position[DepthAxis], upperDim[DepthAxis], lower.position[DepthAxis], lowerDim[DepthAxis])
+}
+
+// overlap returns the length of the intersection of segments [a, a+la) and [b, b+lb).
+func overlap(a, la, b, lb float64) float64 {
+	return max(0, min(a+la, b+lb)-max(a, b))
+}
@@ -0,0 +1,105 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestBox_MinSupport_RejectsFloatingItems tests that PutItem rejects items without enough support below.
+func TestBox_MinSupport_RejectsFloatingItems(t *testing.T) {
+	t.Parallel()
+
+	stable := boxpacker3.NewBox("stable", 100, 100, 100, 1000, boxpacker3.WithMinSupport(0.5))
+	loose := boxpacker3.NewBox("loose", 100, 100, 100, 1000)
+
+	require.InDelta(t, 0.5, stable.GetMinSupport(), 0.0001)
+	require.InDelta(t, 0.0, loose.GetMinSupport(), 0.0001)
+
+	for _, box := range []*boxpacker3.Box{stable, loose} {
+		require.True(t, box.PutItem(boxpacker3.NewItem("base", 10, 10, 10, 1, boxpacker3.WithoutRotation()), boxpacker3.Pivot{}))
+	}
+
+	// Only 4% of the plate rests on the small base item.
+	plate := boxpacker3.NewItem("plate", 50, 10, 50, 1, boxpacker3.WithoutRotation())
+	require.False(t, stable.PutItem(plate, boxpacker3.Pivot{0, 10, 0}), "Floating item must be rejected")
+	require.True(t, loose.PutItem(plate, boxpacker3.Pivot{0, 10, 0}), "Without stability mode any position is allowed")
+	require.InDelta(t, 0.04, loose.GetSupportRatio(plate), 0.0001)
+	require.True(t, loose.IsStable())
+
+	// A cube sitting fully on the base item is accepted.
+	cube := boxpacker3.NewItem("cube", 10, 10, 10, 1)
+	require.True(t, stable.PutItem(cube, boxpacker3.Pivot{0, 10, 0}))
+	require.InDelta(t, 1.0, stable.GetSupportRatio(cube), 0.0001)
+	require.True(t, stable.IsStable())
+}
+
+// TestBox_GetSupportRatio_MultipleSupports tests that support is summed across several items below.
+func TestBox_GetSupportRatio_MultipleSupports(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 100, 100, 100, 1000, boxpacker3.WithMinSupport(1))
+
+	require.True(t, box.PutItem(boxpacker3.NewItem("left", 20, 10, 40, 1, boxpacker3.WithoutRotation()), boxpacker3.Pivot{0, 0, 0}))
+	require.True(t, box.PutItem(boxpacker3.NewItem("right", 20, 10, 40, 1, boxpacker3.WithoutRotation()), boxpacker3.Pivot{20, 0, 0}))
+
+	bridge := boxpacker3.NewItem("bridge", 40, 5, 40, 1, boxpacker3.WithoutRotation())
+	require.True(t, box.PutItem(bridge, boxpacker3.Pivot{0, 10, 0}), "Bridge spans both supports")
+	require.InDelta(t, 1.0, box.GetSupportRatio(bridge), 0.0001)
+}
+
+// TestPacker_WithStability_AllStrategies tests that every strategy produces stable packings in stability mode.
+func TestPacker_WithStability_AllStrategies(t *testing.T) {
+	t.Parallel()
+
+	strategies := []boxpacker3.PackingStrategy{
+		boxpacker3.StrategyMinimizeBoxes,
+		boxpacker3.StrategyGreedy,
+		boxpacker3.StrategyBestFit,
+		boxpacker3.StrategyBestFitDecreasing,
+		boxpacker3.StrategyNextFit,
+		boxpacker3.StrategyWorstFit,
+		boxpacker3.StrategyAlmostWorstFit,
+	}
+
+	const minSupport = 0.75
+
+	for _, strategy := range strategies {
+		t.Run(strategyName(strategy), func(t *testing.T) {
+			t.Parallel()
+
+			boxes := []*boxpacker3.Box{
+				boxpacker3.NewBox("box-1", 100, 100, 100, 10000),
+				boxpacker3.NewBox("box-2", 100, 100, 100, 10000),
+			}
+
+			items := make([]*boxpacker3.Item, 0, 12)
+			for i := range 12 {
+				size := float64(20 + (i%4)*10)
+				items = append(items, boxpacker3.NewItem("item-"+strconv.Itoa(i), size, size/2, size, 10))
+			}
+
+			packer := boxpacker3.NewPacker(
+				boxpacker3.WithStrategy(strategy),
+				boxpacker3.WithStability(minSupport),
+			)
+			result, err := packer.PackCtx(context.Background(), boxes, items)
+			require.NoError(t, err)
+			validatePackingInvariants(t, result)
+
+			for _, box := range result.Boxes {
+				require.InDelta(t, minSupport, box.GetMinSupport(), 0.0001)
+				require.True(t, box.IsStable(), "Box %s must be stable", box.GetID())
+
+				for _, item := range box.GetItems() {
+					require.GreaterOrEqual(t, box.GetSupportRatio(item)+0.0001, minSupport,
+						"Item %s in box %s is not supported", item.GetID(), box.GetID())
+				}
+			}
+		})
+	}
+}
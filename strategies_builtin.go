@@ -115,6 +115,77 @@ func prepareData(inputBoxes []*Box, items []*Item) (boxSlice, *Result) {
 	return sortedBoxes, result
 }
 
+// replenishBoxType keeps an empty instance of a BoxType available once the box at index i
+// has been opened. The new instance is inserted right after the opened one, so strategies
+// that walk boxes in order try it next. The input slice is never modified in place.
+func replenishBoxType(boxes boxSlice, i int) boxSlice {
+	box := boxes[i]
+	if box == nil || box.boxType == nil || len(box.items) == 0 {
+		return boxes
+	}
+
+	instances := 0
+
+	for _, b := range boxes {
+		if b == nil || b.boxType != box.boxType {
+			continue
+		}
+
+		if len(b.items) == 0 {
+			return boxes
+		}
+
+		instances++
+	}
+
+	if !box.boxType.allows(instances) {
+		return boxes
+	}
+
+	result := make(boxSlice, 0, len(boxes)+1)
+	result = append(result, boxes[:i+1]...)
+	result = append(result, box.boxType.NewBox())
+	result = append(result, boxes[i+1:]...)
+
+	return result
+}
+
+// dropSpareBoxes removes the BoxType instances opened during packing that never received an item.
+// Boxes that were part of the prepared input are always kept.
+func dropSpareBoxes(boxes, inputs boxSlice) boxSlice {
+	if len(boxes) == len(inputs) {
+		return boxes
+	}
+
+	original := make(map[*Box]struct{}, len(inputs))
+	for _, b := range inputs {
+		original[b] = struct{}{}
+	}
+
+	result := make(boxSlice, 0, len(boxes))
+
+	for _, b := range boxes {
+		if _, ok := original[b]; ok || len(b.items) > 0 {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// compactItems drops nil items.
+func compactItems(items []*Item) []*Item {
+	result := make([]*Item, 0, len(items))
+
+	for _, it := range items {
+		if it != nil {
+			result = append(result, it)
+		}
+	}
+
+	return result
+}
+
 // checkContext is a small helper to reduce boilerplate.
 func checkContext(ctx context.Context) error {
 	select {
@@ -129,7 +200,7 @@ func runFirstFit(ctx context.Context, boxes []*Box, items []*Item) (*Result, err
 	sortedBoxes, result := prepareData(boxes, items)
 	remainingItems := items
 
-	for _, box := range sortedBoxes {
+	for i := 0; i < len(sortedBoxes); i++ {
 		err := checkContext(ctx)
 		if err != nil {
 			return nil, err
@@ -139,10 +210,13 @@ func runFirstFit(ctx context.Context, boxes []*Box, items []*Item) (*Result, err
 			break
 		}
 
-		remainingItems = packToBox(ctx, box, remainingItems)
+		remainingItems = packToBox(ctx, sortedBoxes[i], remainingItems)
+		sortedBoxes = replenishBoxType(sortedBoxes, i)
 	}
 
 	result.UnfitItems = append(result.UnfitItems, remainingItems...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
 
 	return result, nil
 }
@@ -166,12 +240,15 @@ func runBestFit(ctx context.Context, boxes []*Box, items []*Item) (*Result, erro
 		if bestBoxIndex >= 0 {
 			box := sortedBoxes[bestBoxIndex]
 			box.PutItem(item, bestPivot)
+			sortedBoxes = replenishBoxType(sortedBoxes, bestBoxIndex)
 		} else {
 			unpacked = append(unpacked, item)
 		}
 	}
 
 	result.UnfitItems = append(result.UnfitItems, unpacked...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
 
 	return result, nil
 }
@@ -230,24 +307,16 @@ func evaluateBoxForBestFit(box *Box, item *Item) (Pivot, bool, bool) {
 	return tryPlaceItemInBox(box, item)
 }
 
-// tryPlaceItemInBox attempts to place an item relative to existing items in the box.
+// tryPlaceItemInBox attempts to place an item at the extreme points of the box.
 func tryPlaceItemInBox(box *Box, item *Item) (Pivot, bool, bool) {
-	for j := range box.items {
-		itemPos := box.items[j].position
-		dimension := box.items[j].GetDimension()
-
-		for _, axis := range []Axis{WidthAxis, HeightAxis, DepthAxis} {
-			pv := Pivot{itemPos[WidthAxis], itemPos[HeightAxis], itemPos[DepthAxis]}
-			pv[axis] += dimension[axis]
-
-			testBox := CopyPtr(box)
-			if testBox.PutItem(item, pv) {
-				if testBox.GetRemainingVolume() < perfectFitThreshold {
-					return pv, true, true
-				}
-
-				return pv, true, false
+	for _, pv := range box.extremePoints {
+		testBox := CopyPtr(box)
+		if testBox.PutItem(item, pv) {
+			if testBox.GetRemainingVolume() < perfectFitThreshold {
+				return pv, true, true
 			}
+
+			return pv, true, false
 		}
 	}
 
@@ -292,12 +361,16 @@ func runNextFit(ctx context.Context, boxes []*Box, items []*Item) (*Result, erro
 			}
 		}
 
-		if !fitted {
+		if fitted {
+			sortedBoxes = replenishBoxType(sortedBoxes, currentBoxIndex)
+		} else {
 			unpacked = append(unpacked, item)
 		}
 	}
 
 	result.UnfitItems = append(result.UnfitItems, unpacked...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
 
 	return result, nil
 }
@@ -313,17 +386,9 @@ func fitInSpecificBox(box *Box, item *Item) bool {
 		return true
 	}
 
-	for j := range box.items {
-		itemPos := box.items[j].position
-		dimension := box.items[j].GetDimension()
-
-		for _, axis := range []Axis{WidthAxis, HeightAxis, DepthAxis} {
-			pv := Pivot{itemPos[WidthAxis], itemPos[HeightAxis], itemPos[DepthAxis]}
-
-			pv[axis] += dimension[axis]
-			if box.PutItem(item, pv) {
-				return true
-			}
+	for _, pv := range box.extremePoints {
+		if box.PutItem(item, pv) {
+			return true
 		}
 	}
 
@@ -354,12 +419,15 @@ func runWorstFit(ctx context.Context, boxes []*Box, items []*Item, skipEmpty boo
 		if worstBox >= 0 {
 			box := sortedBoxes[worstBox]
 			box.PutItem(item, worstPivot)
+			sortedBoxes = replenishBoxType(sortedBoxes, worstBox)
 		} else {
 			unpacked = append(unpacked, item)
 		}
 	}
 
 	result.UnfitItems = append(result.UnfitItems, unpacked...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
 
 	return result, nil
 }
@@ -0,0 +1,424 @@
+package boxpacker3
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	defaultNodeLimit = 200_000
+
+	// nodeCheckInterval is the number of nodes between two checks of the context and the time limit.
+	nodeCheckInterval = 256
+)
+
+// BranchAndBoundStrategy is an exact solver for small orders.
+//
+// It searches every assignment of items to boxes, trying each extreme point and allowed rotation
+// of the item, and prunes branches that cannot beat the best packing found so far according to
+// a lower bound on the number of boxes still needed. The search starts from the best result of
+// the MinimizeBoxes and BestFitDecreasing heuristics.
+//
+// The objective is to pack as many items as possible into as few boxes as possible.
+// When the search completes, Result.Optimal is set: no placement at the extreme points uses
+// fewer boxes. When the node limit, the time limit or the context stops the search first,
+// the best packing found so far is returned with Result.Optimal unset.
+type BranchAndBoundStrategy struct {
+	nodeLimit int
+	timeLimit time.Duration
+}
+
+// BranchAndBoundOption defines functional options for configuring the BranchAndBoundStrategy.
+type BranchAndBoundOption func(*BranchAndBoundStrategy)
+
+// WithNodeLimit sets the maximum number of search nodes (200000 by default, 0 for no limit).
+func WithNodeLimit(nodes int) BranchAndBoundOption {
+	return func(s *BranchAndBoundStrategy) {
+		s.nodeLimit = max(nodes, 0)
+	}
+}
+
+// WithTimeLimit sets the maximum search time (no limit by default, the context deadline still applies).
+func WithTimeLimit(d time.Duration) BranchAndBoundOption {
+	return func(s *BranchAndBoundStrategy) {
+		s.timeLimit = d
+	}
+}
+
+// NewBranchAndBoundStrategy creates a new exact solver.
+func NewBranchAndBoundStrategy(opts ...BranchAndBoundOption) *BranchAndBoundStrategy {
+	s := &BranchAndBoundStrategy{
+		nodeLimit: defaultNodeLimit,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the identifier for this strategy.
+func (s *BranchAndBoundStrategy) Name() string {
+	return "BranchAndBound"
+}
+
+// Pack searches for a packing with the fewest unfit items and boxes.
+func (s *BranchAndBoundStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	items = compactItems(items)
+
+	search := &bnbSearch{
+		ctx:       ctx,
+		nodeLimit: s.nodeLimit,
+	}
+
+	if s.timeLimit > 0 {
+		search.deadline = time.Now().Add(s.timeLimit)
+	}
+
+	if err := search.incumbent(ctx, boxes, items); err != nil {
+		return nil, err
+	}
+
+	sortedBoxes, prepared := prepareData(boxes, items)
+	search.inputs = prepared.Boxes
+	search.prepare(sortedBoxes, items)
+
+	if !search.provenByBound(sortedBoxes) {
+		search.run(sortedBoxes, nil, 0)
+	}
+
+	result := search.best
+	result.Optimal = !search.aborted
+
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+
+	return result, nil
+}
+
+type bnbSearch struct {
+	ctx       context.Context //nolint:containedctx // the search is bound to a single Pack call
+	deadline  time.Time
+	nodeLimit int
+	nodes     int
+	aborted   bool
+
+	// items that fit into some box, in decreasing volume; forced are the items that fit into none.
+	items  []*Item
+	forced []*Item
+
+	// remainingVolume[k] and remainingWeight[k] are the totals of items[k:].
+	remainingVolume []float64
+	remainingWeight []float64
+	maxBoxVolume    float64
+	maxBoxWeight    float64
+
+	// inputs are the prepared boxes; other empty boxes are spare BoxType instances.
+	inputs boxSlice
+
+	best      *Result
+	bestUnfit int
+	bestBoxes int
+}
+
+// incumbent seeds the search with the best heuristic result.
+func (s *bnbSearch) incumbent(ctx context.Context, boxes []*Box, items []*Item) error {
+	for _, algo := range []PackingAlgorithm{NewMinimizeBoxesStrategy(), NewBestFitDecreasingStrategy()} {
+		res, err := algo.Pack(ctx, CopySlicePtr(boxes), CopySlicePtr(items))
+		if err != nil {
+			return err
+		}
+
+		if s.best == nil || MinimizeBoxesGoal(res, s.best) {
+			s.best = res
+		}
+	}
+
+	s.bestUnfit = len(s.best.UnfitItems)
+	s.bestBoxes = countUsedBoxes(s.best.Boxes)
+
+	return nil
+}
+
+func (s *bnbSearch) prepare(boxes boxSlice, items []*Item) {
+	items = CopySlicePtr(items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].volume > items[j].volume
+	})
+
+	for _, b := range boxes {
+		if b != nil {
+			s.maxBoxVolume = max(s.maxBoxVolume, b.volume)
+			s.maxBoxWeight = max(s.maxBoxWeight, b.maxWeight)
+		}
+	}
+
+	for _, it := range items {
+		if fitsAnyEmpty(emptyCopies(boxes), it) {
+			s.items = append(s.items, it)
+		} else {
+			s.forced = append(s.forced, it)
+		}
+	}
+
+	s.remainingVolume = make([]float64, len(s.items)+1)
+	s.remainingWeight = make([]float64, len(s.items)+1)
+
+	for k := len(s.items) - 1; k >= 0; k-- {
+		s.remainingVolume[k] = s.remainingVolume[k+1] + s.items[k].volume
+		s.remainingWeight[k] = s.remainingWeight[k+1] + s.items[k].weight
+	}
+}
+
+// provenByBound reports whether the incumbent already meets LowerBound, which is only
+// known when all boxes are of the same kind.
+func (s *bnbSearch) provenByBound(boxes boxSlice) bool {
+	var first *Box
+
+	for _, b := range boxes {
+		if b == nil {
+			continue
+		}
+
+		if first == nil {
+			first = b
+		} else if !sameKind(first, b) {
+			return false
+		}
+	}
+
+	if first == nil || s.bestUnfit != len(s.forced) {
+		return false
+	}
+
+	return s.bestBoxes <= LowerBound(first, s.items)
+}
+
+// stop reports whether a limit was reached.
+func (s *bnbSearch) stop() bool {
+	if s.aborted {
+		return true
+	}
+
+	s.nodes++
+
+	if s.nodeLimit > 0 && s.nodes > s.nodeLimit {
+		s.aborted = true
+	} else if s.nodes%nodeCheckInterval == 0 {
+		s.aborted = s.ctx.Err() != nil || (!s.deadline.IsZero() && time.Now().After(s.deadline))
+	}
+
+	return s.aborted
+}
+
+// run places items[k:] into the boxes; unfit holds the items left out on the current path.
+func (s *bnbSearch) run(boxes boxSlice, unfit []*Item, k int) {
+	if s.stop() {
+		return
+	}
+
+	unfitCount := len(s.forced) + len(unfit)
+	if !s.canImprove(unfitCount, s.lowerBound(boxes, k)) {
+		return
+	}
+
+	if k == len(s.items) {
+		s.record(boxes, unfit)
+
+		return
+	}
+
+	item := s.items[k]
+
+	// Branch 1: every candidate placement in the boxes that are already open.
+	for i, b := range boxes {
+		if b == nil || len(b.items) == 0 || !b.canQuota(item) {
+			continue
+		}
+
+		for _, p := range placements(b, item) {
+			next := make(boxSlice, len(boxes))
+			copy(next, boxes)
+			next[i] = p.apply(item)
+
+			s.run(next, unfit, k+1)
+		}
+	}
+
+	// Branch 2: open one empty box of each kind.
+	var opened []*Box
+
+	for i, b := range boxes {
+		if b == nil || len(b.items) > 0 || !b.canQuota(item) {
+			continue
+		}
+
+		if slices.ContainsFunc(opened, func(o *Box) bool { return sameKind(o, b) }) {
+			continue
+		}
+
+		opened = append(opened, b)
+
+		for _, p := range placements(b, item) {
+			next := make(boxSlice, len(boxes))
+			copy(next, boxes)
+			next[i] = p.apply(item)
+
+			s.run(replenishBoxType(next, i), unfit, k+1)
+		}
+	}
+
+	// Branch 3: leave the item out.
+	s.run(boxes, append(unfit[:len(unfit):len(unfit)], item), k+1)
+}
+
+// lowerBound returns the number of boxes needed at least to hold items[k:] on top of the open boxes.
+func (s *bnbSearch) lowerBound(boxes boxSlice, k int) int {
+	var (
+		open       int
+		freeVolume float64
+		freeWeight float64
+	)
+
+	for _, b := range boxes {
+		if b != nil && len(b.items) > 0 {
+			open++
+			freeVolume += b.volume - b.itemsVolume
+			freeWeight += b.maxWeight - b.itemsWeight
+		}
+	}
+
+	extra := 0
+
+	if s.maxBoxVolume > 0 {
+		extra = max(extra, int(math.Ceil((s.remainingVolume[k]-freeVolume)/s.maxBoxVolume-epsilon)))
+	}
+
+	if s.maxBoxWeight > 0 {
+		extra = max(extra, int(math.Ceil((s.remainingWeight[k]-freeWeight)/s.maxBoxWeight-epsilon)))
+	}
+
+	return open + extra
+}
+
+// canImprove reports whether a branch with the given bounds may beat the best packing.
+func (s *bnbSearch) canImprove(unfit, boxes int) bool {
+	return unfit < s.bestUnfit || (unfit == s.bestUnfit && boxes < s.bestBoxes)
+}
+
+// record stores a complete packing as the new best one. Items are shared between the branches
+// of the search, so the boxes and their items are copied. Spare BoxType instances are dropped.
+func (s *bnbSearch) record(boxes boxSlice, unfit []*Item) {
+	boxes = dropSpareBoxes(boxes, s.inputs)
+
+	result := &Result{
+		Boxes:      make(boxSlice, 0, len(boxes)),
+		UnfitItems: make(itemSlice, 0, len(s.forced)+len(unfit)),
+	}
+
+	for _, b := range boxes {
+		snapshot := CopyPtr(b)
+		if snapshot != nil {
+			snapshot.items = CopySlicePtr(b.items)
+		}
+
+		result.Boxes = append(result.Boxes, snapshot)
+	}
+
+	for _, it := range append(s.forced, unfit...) {
+		unfitItem := CopyPtr(it)
+		unfitItem.position = Pivot{}
+		unfitItem.rotationType = RotationTypeWhd
+		result.UnfitItems = append(result.UnfitItems, unfitItem)
+	}
+
+	s.best = result
+	s.bestUnfit = len(result.UnfitItems)
+	s.bestBoxes = countUsedBoxes(result.Boxes)
+}
+
+// placement is a copy of a box with an item placed in it.
+type placement struct {
+	box       *Box
+	position  Pivot
+	rotation  RotationType
+	dimension Dimension
+}
+
+// apply restores the position and rotation of the item, which is shared by every placement, and returns the box.
+func (p placement) apply(item *Item) *Box {
+	item.position = p.position
+	item.rotationType = p.rotation
+
+	return p.box
+}
+
+// placements returns every distinct extreme point and allowed rotation at which the item fits into the box.
+func placements(b *Box, item *Item) []placement {
+	points := b.GetExtremePoints()
+
+	allowed := item.rotations
+	defer func() { item.rotations = allowed }()
+
+	seen := make(map[[2]Pivot]struct{})
+	result := make([]placement, 0, len(points))
+
+	for _, pv := range points {
+		for rt := RotationTypeWhd; rt <= RotationTypeWdh; rt++ {
+			item.rotations = allowed
+			if !item.allowsRotation(rt) {
+				continue
+			}
+
+			item.rotations = 1 << rt
+
+			child := CopyPtr(b)
+			if !child.PutItem(item, pv) {
+				continue
+			}
+
+			dim := item.GetDimension()
+
+			key := [2]Pivot{pv, Pivot(dim)}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			result = append(result, placement{box: child, position: pv, rotation: rt, dimension: dim})
+		}
+	}
+
+	return result
+}
+
+// sameKind reports whether two empty boxes are interchangeable: they have the same size, limits and type.
+func sameKind(a, b *Box) bool {
+	return a.id == b.id &&
+		a.width == b.width && a.height == b.height && a.depth == b.depth &&
+		a.maxWeight == b.maxWeight && a.minSupport == b.minSupport && a.cost == b.cost &&
+		a.boxType == b.boxType && a.strip == b.strip && a.pallet == b.pallet && a.overhang == b.overhang &&
+		slices.Equal(a.axles, b.axles) &&
+		(a.cogLimits == b.cogLimits || (a.cogLimits != nil && b.cogLimits != nil && *a.cogLimits == *b.cogLimits))
+}
+
+func emptyCopies(boxes []*Box) []*Box {
+	result := make([]*Box, 0, len(boxes))
+
+	for _, b := range boxes {
+		if b != nil {
+			e := CopyPtr(b)
+			e.Reset()
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package boxpacker3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSameKind tests that boxes differing in any limit are not interchangeable.
+func TestSameKind(t *testing.T) {
+	t.Parallel()
+
+	box := func(opts ...BoxOption) *Box { return NewBox("box", 100, 100, 100, 1000, opts...) }
+
+	require.True(t, sameKind(box(), box()))
+	require.True(t, sameKind(
+		box(WithCenterOfGravityLimits(Pivot{40, 0, 0}, Pivot{60, 100, 100})),
+		box(WithCenterOfGravityLimits(Pivot{40, 0, 0}, Pivot{60, 100, 100})),
+	))
+
+	for name, other := range map[string]*Box{
+		"size":         NewBox("box", 100, 100, 90, 1000),
+		"weight":       NewBox("box", 100, 100, 100, 900),
+		"support":      box(WithMinSupport(0.5)),
+		"cost":         box(WithCost(2)),
+		"axles":        box(WithAxles(Axle{10, 500}, Axle{90, 500})),
+		"gravity":      box(WithCenterOfGravityLimits(Pivot{40, 0, 0}, Pivot{60, 100, 100})),
+		"strip":        NewStrip("box", 100, 1000),
+		"pallet":       NewPallet("box", 100, 100, 100, 1000),
+		"overhang":     NewPallet("box", 80, 80, 100, 1000, WithOverhang(10)),
+		"box type":     NewBoxType(box(), UnlimitedQuantity).NewBox(),
+		"other pallet": NewPallet("box", 80, 100, 100, 1000, WithOverhang(10)),
+	} {
+		require.False(t, sameKind(box(), other), name)
+	}
+
+	require.False(t, sameKind(
+		box(WithCenterOfGravityLimits(Pivot{40, 0, 0}, Pivot{60, 100, 100})),
+		box(WithCenterOfGravityLimits(Pivot{40, 0, 0}, Pivot{70, 100, 100})),
+	))
+}
@@ -0,0 +1,103 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+func exactFixture() ([]*boxpacker3.Box, []*boxpacker3.Item) {
+	dims := [][3]float64{
+		{72, 68, 23}, {50, 30, 68}, {37, 68, 48}, {76, 22, 55}, {37, 71, 52}, {73, 56, 74},
+		{48, 31, 34}, {55, 45, 64}, {30, 68, 57}, {20, 21, 40}, {63, 54, 43},
+	}
+
+	items := make([]*boxpacker3.Item, 0, len(dims))
+	for i, d := range dims {
+		items = append(items, boxpacker3.NewItem("item-"+strconv.Itoa(i), d[0], d[1], d[2], 1))
+	}
+
+	boxes := boxpacker3.BoxesFromTypes(
+		boxpacker3.NewBoxType(boxpacker3.NewBox("box", 100, 100, 100, 1000), boxpacker3.UnlimitedQuantity),
+	)
+
+	return boxes, items
+}
+
+// TestBranchAndBound_SavesABox tests that the exact solver proves a packing with fewer boxes than the heuristics.
+func TestBranchAndBound_SavesABox(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := exactFixture()
+
+	heuristic, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.False(t, heuristic.Optimal)
+
+	strategy := boxpacker3.NewBranchAndBoundStrategy()
+	require.Equal(t, "BranchAndBound", strategy.Name())
+
+	exact, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.True(t, exact.Optimal)
+	require.Empty(t, exact.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, exact))
+	require.Less(t, boxpacker3.BoxCountMetric(exact), boxpacker3.BoxCountMetric(heuristic))
+
+	for _, b := range exact.Boxes {
+		require.NotEmpty(t, b.GetItems(), "Spare instances of the box type are dropped")
+	}
+}
+
+// TestBranchAndBound_Limits tests that a stopped search returns the best packing without the optimality flag.
+func TestBranchAndBound_Limits(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := exactFixture()
+
+	result, err := boxpacker3.NewBranchAndBoundStrategy(boxpacker3.WithNodeLimit(10)).Pack(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.False(t, result.Optimal)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = boxpacker3.NewBranchAndBoundStrategy().Pack(canceled, boxes, items)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestBranchAndBound_Unfit tests that items fitting no box are reported and the rest is still proven optimal.
+func TestBranchAndBound_Unfit(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{
+		boxpacker3.NewBox("a", 10, 10, 10, 10),
+		boxpacker3.NewBox("b", 10, 10, 10, 10),
+	}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("huge", 20, 1, 1, 1),
+		boxpacker3.NewItem("x", 10, 10, 5, 6),
+		boxpacker3.NewItem("y", 10, 10, 5, 6),
+		boxpacker3.NewItem("z", 10, 10, 5, 6),
+	}
+
+	result, err := boxpacker3.NewBranchAndBoundStrategy().Pack(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.True(t, result.Optimal)
+	require.Len(t, result.UnfitItems, 2, "Only one heavy item fits per box")
+	require.Equal(t, 2, int(boxpacker3.BoxCountMetric(result)))
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	reasons := map[string]boxpacker3.UnfitReason{}
+	for _, it := range result.UnfitItems {
+		reasons[it.GetID()] = it.GetUnfitReason()
+	}
+
+	require.Equal(t, boxpacker3.UnfitReasonTooLarge, reasons["huge"])
+}
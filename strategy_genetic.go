@@ -0,0 +1,356 @@
+package boxpacker3
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+)
+
+const (
+	defaultPopulationSize = 30
+	defaultGenerations    = 50
+	defaultMutationRate   = 0.1
+	tournamentSize        = 3
+
+	// anyRotation is the rotation gene that leaves the choice of the orientation to PutItem.
+	anyRotation = -1
+)
+
+// GeneticStrategy evolves item orderings and preferred rotations with a genetic algorithm.
+//
+// Every individual is decoded with first-fit placement: items are taken in the order of the
+// individual and put into the first box that has room, trying the preferred rotation of the
+// item first. Individuals are ranked by the configured goal (any ComparatorFunc from goals.go).
+//
+// The search is deterministic for a given seed. When the context is canceled, the best
+// individual found so far is returned.
+type GeneticStrategy struct {
+	populationSize int
+	generations    int
+	mutationRate   float64
+	seed           uint64
+	goal           ComparatorFunc
+}
+
+// GeneticOption defines functional options for configuring the GeneticStrategy.
+type GeneticOption func(*GeneticStrategy)
+
+// WithPopulationSize sets the number of individuals per generation (30 by default).
+func WithPopulationSize(size int) GeneticOption {
+	return func(s *GeneticStrategy) {
+		s.populationSize = max(size, 2) //nolint:mnd // crossover needs two parents
+	}
+}
+
+// WithGenerations sets the number of generations to evolve (50 by default).
+func WithGenerations(generations int) GeneticOption {
+	return func(s *GeneticStrategy) {
+		s.generations = max(generations, 0)
+	}
+}
+
+// WithMutationRate sets the probability (0..1) of mutating each offspring (0.1 by default).
+func WithMutationRate(rate float64) GeneticOption {
+	return func(s *GeneticStrategy) {
+		s.mutationRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithSeed sets the seed of the random number generator, so runs can be reproduced.
+func WithSeed(seed uint64) GeneticOption {
+	return func(s *GeneticStrategy) {
+		s.seed = seed
+	}
+}
+
+// WithFitness sets the comparator used to rank individuals (MinimizeBoxesGoal by default).
+func WithFitness(goal ComparatorFunc) GeneticOption {
+	return func(s *GeneticStrategy) {
+		s.goal = goal
+	}
+}
+
+// NewGeneticStrategy creates a new genetic strategy.
+//
+// Usage:
+//
+//	strategy := NewGeneticStrategy(
+//	    WithPopulationSize(50),
+//	    WithGenerations(100),
+//	    WithSeed(42),
+//	    WithFitness(TightestPackingGoal),
+//	)
+func NewGeneticStrategy(opts ...GeneticOption) *GeneticStrategy {
+	s := &GeneticStrategy{
+		populationSize: defaultPopulationSize,
+		generations:    defaultGenerations,
+		mutationRate:   defaultMutationRate,
+		seed:           1,
+		goal:           MinimizeBoxesGoal,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the identifier for this strategy.
+func (s *GeneticStrategy) Name() string {
+	return "Genetic"
+}
+
+// individual is an item ordering with a preferred rotation per item (indexed by input position).
+type individual struct {
+	order     []int
+	rotations []int
+	result    *Result
+}
+
+// Pack evolves the population and returns the best decoded individual.
+func (s *GeneticStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	items = compactItems(items)
+	rng := rand.New(rand.NewPCG(s.seed, s.seed)) //nolint:gosec // reproducibility matters, not security
+
+	var best *individual
+
+	evaluate := func(ind *individual) bool {
+		if checkContext(ctx) != nil {
+			return false
+		}
+
+		ind.result = decodeFirstFit(boxes, items, ind.order, ind.rotations)
+
+		if best == nil || s.goal(ind.result, best.result) {
+			best = ind
+		}
+
+		return true
+	}
+
+	population := s.initialPopulation(rng, items)
+
+	for _, ind := range population {
+		if !evaluate(ind) {
+			return s.bestResult(ctx, boxes, best)
+		}
+	}
+
+	for range s.generations {
+		s.rank(population)
+
+		next := make([]*individual, 0, len(population))
+		next = append(next, population[0]) // elitism
+
+		for len(next) < len(population) {
+			child := crossover(rng, s.tournament(rng, population), s.tournament(rng, population))
+			if rng.Float64() < s.mutationRate {
+				mutate(rng, child, items)
+			}
+
+			if !evaluate(child) {
+				return s.bestResult(ctx, boxes, best)
+			}
+
+			next = append(next, child)
+		}
+
+		population = next
+	}
+
+	return s.bestResult(ctx, boxes, best)
+}
+
+// bestResult explains the unfit items of the best individual, or returns the context error
+// when no individual was decoded. The best individual was decoded completely, so its items
+// are never reported as canceled.
+func (s *GeneticStrategy) bestResult(ctx context.Context, boxes []*Box, best *individual) (*Result, error) {
+	if best == nil {
+		return nil, ctx.Err()
+	}
+
+	ExplainUnfit(context.WithoutCancel(ctx), boxes, best.result.UnfitItems)
+
+	return best.result, nil
+}
+
+// initialPopulation seeds the population with the volume-decreasing and -increasing orders
+// used by the built-in heuristics and fills the rest with random individuals.
+func (s *GeneticStrategy) initialPopulation(rng *rand.Rand, items []*Item) []*individual {
+	byVolume := make([]int, len(items))
+	for i := range byVolume {
+		byVolume[i] = i
+	}
+
+	sort.SliceStable(byVolume, func(a, b int) bool {
+		return items[byVolume[a]].volume > items[byVolume[b]].volume
+	})
+
+	ascending := make([]int, len(byVolume))
+	for i, idx := range byVolume {
+		ascending[len(byVolume)-1-i] = idx
+	}
+
+	population := []*individual{
+		{order: byVolume, rotations: anyRotations(len(items))},
+		{order: ascending, rotations: anyRotations(len(items))},
+	}
+
+	for len(population) < s.populationSize {
+		ind := &individual{order: rng.Perm(len(items)), rotations: make([]int, len(items))}
+		for i, it := range items {
+			ind.rotations[i] = randomRotation(rng, it)
+		}
+
+		population = append(population, ind)
+	}
+
+	return population[:s.populationSize]
+}
+
+// rank sorts the population from the best to the worst individual.
+func (s *GeneticStrategy) rank(population []*individual) {
+	sort.SliceStable(population, func(a, b int) bool {
+		return s.goal(population[a].result, population[b].result)
+	})
+}
+
+// tournament picks the best of a few random individuals.
+func (s *GeneticStrategy) tournament(rng *rand.Rand, population []*individual) *individual {
+	var winner *individual
+
+	for range tournamentSize {
+		candidate := population[rng.IntN(len(population))]
+		if winner == nil || s.goal(candidate.result, winner.result) {
+			winner = candidate
+		}
+	}
+
+	return winner
+}
+
+// crossover combines the parents with order crossover (OX1) for the ordering
+// and uniform crossover for the rotations.
+func crossover(rng *rand.Rand, a, b *individual) *individual {
+	n := len(a.order)
+	child := &individual{order: make([]int, 0, n), rotations: make([]int, n)}
+
+	for i := range child.rotations {
+		if rng.IntN(2) == 0 { //nolint:mnd
+			child.rotations[i] = a.rotations[i]
+		} else {
+			child.rotations[i] = b.rotations[i]
+		}
+	}
+
+	if n == 0 {
+		return child
+	}
+
+	lo, hi := rng.IntN(n), rng.IntN(n)
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	taken := make([]bool, n)
+	for _, idx := range a.order[lo : hi+1] {
+		taken[idx] = true
+	}
+
+	rest := make([]int, 0, n)
+
+	for _, idx := range b.order {
+		if !taken[idx] {
+			rest = append(rest, idx)
+		}
+	}
+
+	child.order = append(child.order, rest[:lo]...)
+	child.order = append(child.order, a.order[lo:hi+1]...)
+	child.order = append(child.order, rest[lo:]...)
+
+	return child
+}
+
+// mutate swaps two items of the ordering and re-rolls the rotation of one item.
+func mutate(rng *rand.Rand, ind *individual, items []*Item) {
+	n := len(ind.order)
+	if n == 0 {
+		return
+	}
+
+	i, j := rng.IntN(n), rng.IntN(n)
+	ind.order[i], ind.order[j] = ind.order[j], ind.order[i]
+
+	k := rng.IntN(n)
+	ind.rotations[k] = randomRotation(rng, items[k])
+}
+
+func randomRotation(rng *rand.Rand, item *Item) int {
+	allowed := item.GetAllowedRotations()
+
+	// One extra slot keeps "any rotation" in the gene pool.
+	if k := rng.IntN(len(allowed) + 1); k < len(allowed) {
+		return int(allowed[k])
+	}
+
+	return anyRotation
+}
+
+func anyRotations(n int) []int {
+	rotations := make([]int, n)
+	for i := range rotations {
+		rotations[i] = anyRotation
+	}
+
+	return rotations
+}
+
+// decodeFirstFit packs copies of the items in the given order, putting each one into the first
+// box that has room. The preferred rotation of an item (or anyRotation) is tried first.
+// Unfit items are not explained, Pack does it once for the best individual.
+func decodeFirstFit(inputBoxes []*Box, inputItems []*Item, order, rotations []int) *Result {
+	boxes, result := prepareData(inputBoxes, inputItems)
+	items := CopySlicePtr(inputItems)
+
+	for _, idx := range order {
+		item := items[idx]
+		placed := false
+
+		for i := 0; i < len(boxes) && !placed; i++ {
+			placed = fitWithPreferredRotation(boxes[i], item, rotations[idx])
+			if placed {
+				boxes = replenishBoxType(boxes, i)
+			}
+		}
+
+		if !placed {
+			result.UnfitItems = append(result.UnfitItems, item)
+		}
+	}
+
+	result.Boxes = dropSpareBoxes(boxes, result.Boxes)
+
+	return result
+}
+
+// fitWithPreferredRotation tries the preferred rotation first, then every allowed rotation.
+func fitWithPreferredRotation(box *Box, item *Item, rotation int) bool {
+	if rotation != anyRotation && item.allowsRotation(RotationType(rotation)) {
+		allowed := item.rotations
+		item.rotations = 1 << rotation
+		placed := fitInSpecificBox(box, item)
+		item.rotations = allowed
+
+		if placed {
+			return true
+		}
+	}
+
+	return fitInSpecificBox(box, item)
+}
@@ -0,0 +1,123 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+func geneticFixture() ([]*boxpacker3.Box, []*boxpacker3.Item) {
+	boxes := boxpacker3.BoxesFromTypes(
+		boxpacker3.NewBoxType(boxpacker3.NewBox("small", 40, 40, 40, 1000), boxpacker3.UnlimitedQuantity),
+		boxpacker3.NewBoxType(boxpacker3.NewBox("large", 80, 60, 60, 1000), boxpacker3.UnlimitedQuantity),
+	)
+
+	items := make([]*boxpacker3.Item, 0, 24)
+	for i := range 24 {
+		items = append(items, boxpacker3.NewItem("item-"+strconv.Itoa(i),
+			float64(10+i%4*7), float64(12+i%3*9), float64(8+i%5*5), float64(1+i%7), boxpacker3.WithThisSideUp()))
+	}
+
+	return boxes, items
+}
+
+// TestGeneticStrategy_Valid tests that the genetic strategy produces valid, reproducible results.
+func TestGeneticStrategy_Valid(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := geneticFixture()
+	strategy := boxpacker3.NewGeneticStrategy(
+		boxpacker3.WithPopulationSize(12),
+		boxpacker3.WithGenerations(10),
+		boxpacker3.WithMutationRate(0.3),
+		boxpacker3.WithSeed(7),
+		boxpacker3.WithFitness(boxpacker3.TightestPackingGoal),
+	)
+	require.Equal(t, "Genetic", strategy.Name())
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy))
+
+	first, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, first.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, first))
+
+	requireReproducible(t, packer, boxes, items)
+}
+
+// TestGeneticStrategy_NotWorseThanHeuristics tests that evolving never loses to the built-in strategies
+// on the fixture under the chosen goal.
+func TestGeneticStrategy_NotWorseThanHeuristics(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := geneticFixture()
+
+	genetic, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewGeneticStrategy())).
+		PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		result, err := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy)).PackCtx(context.Background(), boxes, items)
+		require.NoError(t, err)
+		require.False(t, boxpacker3.MinimizeBoxesGoal(result, genetic), strategy.String())
+	}
+}
+
+// TestGeneticStrategy_Context tests that a canceled context fails and an expiring one returns the best so far.
+func TestGeneticStrategy_Context(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := geneticFixture()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := boxpacker3.NewGeneticStrategy().Pack(canceled, boxes, items)
+	require.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	strategy := boxpacker3.NewGeneticStrategy(boxpacker3.WithGenerations(1_000_000))
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(ctx, boxes, items)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+}
+
+// TestGeneticStrategy_Unfit tests that the best result explains its unfit items, even when the search is cut short.
+func TestGeneticStrategy_Unfit(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{boxpacker3.NewBox("small", 40, 40, 40, 1000)}
+	items := []*boxpacker3.Item{boxpacker3.NewItem("huge", 100, 1, 1, 1)}
+
+	for i := range 9 {
+		items = append(items, boxpacker3.NewItem("cube-"+strconv.Itoa(i), 20, 20, 20, 1))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	strategy := boxpacker3.NewGeneticStrategy(boxpacker3.WithGenerations(1_000_000))
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(ctx, boxes, items)
+	require.NoError(t, err)
+	require.Len(t, result.UnfitItems, 2)
+
+	reasons := map[string]boxpacker3.UnfitReason{}
+	for _, item := range result.UnfitItems {
+		reasons[item.GetID()] = item.GetUnfitReason()
+	}
+
+	require.Equal(t, boxpacker3.UnfitReasonTooLarge, reasons["huge"])
+	delete(reasons, "huge")
+
+	for _, reason := range reasons {
+		require.Equal(t, boxpacker3.UnfitReasonNoSpace, reason)
+	}
+}
@@ -0,0 +1,226 @@
+package boxpacker3
+
+import (
+	"context"
+	"sort"
+)
+
+// Cut is a straight edge-to-edge saw cut through a piece of a sheet.
+//
+// A vertical cut runs from (X, Y) to (X, Y+Length), a horizontal cut from (X, Y) to (X+Length, Y).
+// The kerf removed by the blade starts at the cut line: X..X+kerf for vertical cuts, Y..Y+kerf for horizontal ones.
+type Cut struct {
+	Vertical bool    `json:"vertical"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Length   float64 `json:"length"`
+}
+
+// GetCuts returns the ordered cut sequence of a sheet packed by the GuillotineStrategy.
+// Every cut goes through a piece that earlier cuts have already separated from the rest of the sheet.
+func (b *Box) GetCuts() []Cut {
+	return append([]Cut(nil), b.cuts...)
+}
+
+// GuillotineStrategy is a 2D packing mode for panel saws that can only make guillotine cuts,
+// i.e. straight cuts from one edge of a piece to the opposite one.
+//
+// Every sheet is split recursively: an item is put into the corner of a free piece, and the rest
+// of the piece is cut off along the shorter leftover side first, which keeps the larger offcut
+// whole. Each cut removes the kerf (blade width) from the material. Items are taken by best area
+// fit, as is or turned by 90° when their allowed rotations permit, and sheets are filled one after
+// another. The cuts of each sheet are available from Box.GetCuts in the order they have to be made.
+// Placements breaking the stability, load or balance limits of the box are skipped, as Box.PutItem does.
+type GuillotineStrategy struct {
+	kerf float64
+}
+
+// GuillotineOption defines functional options for configuring the GuillotineStrategy.
+type GuillotineOption func(*GuillotineStrategy)
+
+// WithKerf sets the width of material removed by each cut (0 by default).
+func WithKerf(kerf float64) GuillotineOption {
+	return func(s *GuillotineStrategy) {
+		s.kerf = max(kerf, 0)
+	}
+}
+
+// NewGuillotineStrategy creates a new guillotine-cut strategy.
+//
+// Usage:
+//
+//	strategy := NewGuillotineStrategy(WithKerf(3.2))
+//	result, err := NewPacker(WithAlgorithm(strategy)).PackCtx(ctx, sheets, panels)
+func NewGuillotineStrategy(opts ...GuillotineOption) *GuillotineStrategy {
+	s := &GuillotineStrategy{}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the identifier for this strategy.
+func (s *GuillotineStrategy) Name() string {
+	return "Guillotine"
+}
+
+// Pack cuts the items out of the sheets one sheet after another.
+func (s *GuillotineStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	remaining := compactItems(items)
+	sort.SliceStable(remaining, func(a, b int) bool {
+		return remaining[a].whd[WidthAxis]*remaining[a].whd[HeightAxis] >
+			remaining[b].whd[WidthAxis]*remaining[b].whd[HeightAxis]
+	})
+
+	sortedBoxes, result := prepareData(boxes, remaining)
+
+	for i := 0; i < len(sortedBoxes) && len(remaining) > 0; i++ {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+
+		// Sheets that already hold items have no known cut plan.
+		if sortedBoxes[i] == nil || len(sortedBoxes[i].items) > 0 {
+			continue
+		}
+
+		sheet := &guillotineSheet{
+			box:  sortedBoxes[i],
+			kerf: s.kerf,
+			free: []rect{{w: sortedBoxes[i].width, h: sortedBoxes[i].height}},
+		}
+
+		remaining = sheet.fill(remaining)
+		sortedBoxes = replenishBoxType(sortedBoxes, i)
+	}
+
+	result.UnfitItems = append(result.UnfitItems, remaining...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
+
+	return result, nil
+}
+
+// guillotineSheet tracks the disjoint free pieces of a sheet.
+type guillotineSheet struct {
+	box  *Box
+	kerf float64
+	free []rect
+}
+
+// fill repeatedly cuts out the item with the best area fit of all remaining items
+// and returns the items left over.
+func (g *guillotineSheet) fill(items []*Item) []*Item {
+	rest := append([]*Item(nil), items...)
+
+	for len(rest) > 0 {
+		bestIndex, bestPiece := -1, -1
+
+		var best maxRectsCandidate
+
+		for k, item := range rest {
+			if !g.box.canQuota(item) {
+				continue
+			}
+
+			for p, piece := range g.free {
+				if c, ok := g.candidate(item, piece); ok && (bestIndex < 0 || c.better(best)) {
+					bestIndex, bestPiece, best = k, p, c
+				}
+			}
+		}
+
+		if bestIndex < 0 {
+			break
+		}
+
+		item := rest[bestIndex]
+		item.position = Pivot{best.rect.x, best.rect.y, 0}
+		item.setRotationType(best.rotation)
+		g.box.insert(item)
+		g.split(bestPiece, best.rect)
+
+		rest = append(rest[:bestIndex], rest[bestIndex+1:]...)
+	}
+
+	return rest
+}
+
+// candidate returns the best orientation of the item in the corner of the piece.
+// Orientations breaking the stability, load or balance limits of the box are skipped.
+func (g *guillotineSheet) candidate(item *Item, piece rect) (maxRectsCandidate, bool) {
+	var (
+		best  maxRectsCandidate
+		found bool
+	)
+
+	for _, rt := range []RotationType{RotationTypeWhd, RotationTypeHwd} {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
+		matrix := rotationMatrix[rt]
+		w, h, d := item.whd[matrix[WidthAxis]], item.whd[matrix[HeightAxis]], item.whd[matrix[DepthAxis]]
+
+		if d > g.box.depth || w > piece.w+pointTolerance || h > piece.h+pointTolerance ||
+			!g.box.meetsLimitsAt(item, Pivot{piece.x, piece.y, 0}, rt) {
+			continue
+		}
+
+		c := maxRectsCandidate{rect{piece.x, piece.y, w, h}, rt, MaxRectsBestAreaFit.score(piece, w, h)}
+		if !found || c.better(best) {
+			best, found = c, true
+		}
+	}
+
+	return best, found
+}
+
+// split cuts the used corner out of the free piece. The cut along the shorter leftover side
+// goes first and spans the whole piece; the second cut frees the item from its strip.
+func (g *guillotineSheet) split(index int, used rect) {
+	piece := g.free[index]
+	g.free = append(g.free[:index], g.free[index+1:]...)
+
+	leftoverW, leftoverH := piece.w-used.w, piece.h-used.h
+	right, top := leftoverW > pointTolerance, leftoverH > pointTolerance
+
+	if leftoverW <= leftoverH {
+		// Horizontal cut across the piece, then a vertical cut through the bottom strip.
+		if top {
+			g.cut(Cut{X: piece.x, Y: used.y + used.h, Length: piece.w})
+			g.addFree(rect{piece.x, used.y + used.h + g.kerf, piece.w, leftoverH - g.kerf})
+		}
+
+		if right {
+			g.cut(Cut{Vertical: true, X: used.x + used.w, Y: piece.y, Length: used.h})
+			g.addFree(rect{used.x + used.w + g.kerf, piece.y, leftoverW - g.kerf, used.h})
+		}
+
+		return
+	}
+
+	// Vertical cut across the piece, then a horizontal cut through the left strip.
+	if right {
+		g.cut(Cut{Vertical: true, X: used.x + used.w, Y: piece.y, Length: piece.h})
+		g.addFree(rect{used.x + used.w + g.kerf, piece.y, leftoverW - g.kerf, piece.h})
+	}
+
+	if top {
+		g.cut(Cut{X: piece.x, Y: used.y + used.h, Length: used.w})
+		g.addFree(rect{piece.x, used.y + used.h + g.kerf, used.w, leftoverH - g.kerf})
+	}
+}
+
+func (g *guillotineSheet) cut(c Cut) {
+	g.box.cuts = append(g.box.cuts, c)
+}
+
+// addFree keeps the piece unless the kerf consumed it.
+func (g *guillotineSheet) addFree(r rect) {
+	if r.w > pointTolerance && r.h > pointTolerance {
+		g.free = append(g.free, r)
+	}
+}
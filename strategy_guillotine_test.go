@@ -0,0 +1,149 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// requireGuillotine checks that no cut crosses an item and that items keep the kerf apart.
+func requireGuillotine(t *testing.T, box *boxpacker3.Box, kerf float64) {
+	t.Helper()
+
+	placements := box.GetPlacements2D()
+
+	for _, c := range box.GetCuts() {
+		for _, p := range placements {
+			if c.Vertical {
+				require.False(t, c.X+kerf > p.X && c.X < p.X+p.Width && c.Y < p.Y+p.Height && c.Y+c.Length > p.Y,
+					"vertical cut %+v crosses %s", c, p.Item.GetID())
+			} else {
+				require.False(t, c.Y+kerf > p.Y && c.Y < p.Y+p.Height && c.X < p.X+p.Width && c.X+c.Length > p.X,
+					"horizontal cut %+v crosses %s", c, p.Item.GetID())
+			}
+		}
+	}
+}
+
+// TestGuillotineStrategy_Cuts tests the cut sequence of a simple layout.
+func TestGuillotineStrategy_Cuts(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{boxpacker3.NewBox2D("sheet", 100, 100, 1000)}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("wide", 100, 60, 1),
+		boxpacker3.NewItem2D("left", 30, 38, 1),
+		boxpacker3.NewItem2D("right", 68, 38, 1),
+	}
+
+	strategy := boxpacker3.NewGuillotineStrategy(boxpacker3.WithKerf(2))
+	require.Equal(t, "Guillotine", strategy.Name())
+
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Len(t, result.Boxes, 1)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	// The wide panel is cut off first, then the strip above it is split into the two small panels.
+	require.Equal(t, []boxpacker3.Cut{
+		{X: 0, Y: 60, Length: 100},
+		{Vertical: true, X: 68, Y: 62, Length: 38},
+	}, result.Boxes[0].GetCuts())
+	requireGuillotine(t, result.Boxes[0], 2)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var decoded boxpacker3.Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, result.Boxes[0].GetCuts(), decoded.Boxes[0].GetCuts())
+}
+
+// TestGuillotineStrategy_Kerf tests that the kerf is kept between items.
+func TestGuillotineStrategy_Kerf(t *testing.T) {
+	t.Parallel()
+
+	boxes := boxpacker3.BoxesFromTypes(
+		boxpacker3.NewBoxType(boxpacker3.NewBox2D("sheet", 100, 100, 1000), boxpacker3.UnlimitedQuantity),
+	)
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("half", 50, 100, 1),
+		boxpacker3.NewItem2D("half", 50, 100, 1),
+	}
+
+	pack := func(kerf float64) *boxpacker3.Result {
+		result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(
+			boxpacker3.NewGuillotineStrategy(boxpacker3.WithKerf(kerf)),
+		)).PackCtx(context.Background(), boxes, items)
+		require.NoError(t, err)
+		require.Empty(t, result.UnfitItems)
+
+		return result
+	}
+
+	require.InDelta(t, 1.0, boxpacker3.BoxCountMetric(pack(0)), 0)
+	require.InDelta(t, 2.0, boxpacker3.BoxCountMetric(pack(1)), 0, "The blade needs room between the halves")
+}
+
+// TestGuillotineStrategy_Limits tests that cut layouts keep the load and stability limits of the sheets.
+func TestGuillotineStrategy_Limits(t *testing.T) {
+	t.Parallel()
+
+	boxes := boxpacker3.BoxesFromTypes(
+		boxpacker3.NewBoxType(boxpacker3.NewBox2D("s", 10, 10, 1000), boxpacker3.UnlimitedQuantity),
+	)
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("glass", 10, 5, 1, boxpacker3.WithFragile()),
+		boxpacker3.NewItem2D("anvil", 10, 5, 50),
+	}
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewGuillotineStrategy()))
+
+	result, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result), "Nothing rests on the glass")
+
+	boxes, items = sheetFixture()
+	packer = boxpacker3.NewPacker(
+		boxpacker3.WithAlgorithm(boxpacker3.NewGuillotineStrategy()),
+		boxpacker3.WithStability(1),
+	)
+
+	result, err = packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+}
+
+// TestGuillotineStrategy_Random tests that random layouts can be cut with guillotine cuts.
+func TestGuillotineStrategy_Random(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := sheetFixture()
+	strategy := boxpacker3.NewGuillotineStrategy(boxpacker3.WithKerf(0.5))
+
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	for _, b := range result.Boxes {
+		require.NotEmpty(t, b.GetCuts())
+		requireGuillotine(t, b, 0.5)
+	}
+
+	panels := seededItems(2, 20, 10, 70, true)
+
+	result, err = boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, panels)
+	require.NoError(t, err)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, panels, result))
+
+	for _, b := range result.Boxes {
+		requireGuillotine(t, b, 0.5)
+	}
+}
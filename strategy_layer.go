@@ -0,0 +1,335 @@
+package boxpacker3
+
+import (
+	"context"
+	"sort"
+)
+
+// LayerStrategy builds pallets in layers, the way cartons are palletised by hand or by a robot.
+//
+// Items of the same size, weight and constraints form a group. On every box, full layers of a single group
+// are stacked from the floor upwards. A layer covers the whole footprint with a two-block pattern: rows of
+// one orientation of the carton followed by rows of another one with the same height, which fills footprints
+// a plain grid leaves gaps in. The corner-pivot strategies cannot produce such patterns, because they place
+// one item at a time. The group whose layer covers the footprint best goes first, and a layer is only formed
+// when enough items of the group are left to complete it. The rest of the items is mixed on top, largest first,
+// at the extreme points of the load. Boxes are filled one after another, like the MinimizeBoxes strategy does.
+//
+// It is meant for pallets created by NewPallet, including the overhang (see WithOverhang), but works with any box.
+// Layers are placed with Box.PutItem, so stability, load and balance limits of the box are respected.
+type LayerStrategy struct{}
+
+// NewLayerStrategy creates a new layer-building strategy.
+//
+// Usage:
+//
+//	pallet := NewPallet("euro", 1200, 800, 1500, 1000000, WithOverhang(20))
+//	result, err := NewPacker(WithAlgorithm(NewLayerStrategy())).PackCtx(ctx, []*Box{pallet}, cartons)
+func NewLayerStrategy() *LayerStrategy {
+	return &LayerStrategy{}
+}
+
+// Name returns the identifier for this strategy.
+func (s *LayerStrategy) Name() string {
+	return "Layer"
+}
+
+// Pack builds the boxes one after another, full layers first.
+func (s *LayerStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	remaining := compactItems(items)
+	sortedBoxes, result := prepareData(boxes, remaining)
+
+	for i := 0; i < len(sortedBoxes) && len(remaining) > 0; i++ {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+
+		if sortedBoxes[i] == nil {
+			continue
+		}
+
+		remaining = buildLayers(sortedBoxes[i], remaining)
+		remaining = mixItems(sortedBoxes[i], remaining)
+		sortedBoxes = replenishBoxType(sortedBoxes, i)
+	}
+
+	result.UnfitItems = append(result.UnfitItems, remaining...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
+
+	return result, nil
+}
+
+// itemKind identifies items that are interchangeable in a layer.
+type itemKind struct {
+	whd         [3]float64
+	weight      float64
+	rotations   uint8
+	maxLoad     float64
+	loadLimited bool
+}
+
+func kindOfItem(i *Item) itemKind {
+	return itemKind{i.whd, i.weight, i.rotations, i.maxLoad, i.loadLimited}
+}
+
+// buildLayers stacks full layers of homogeneous items in the box and returns the items left over, in their order.
+func buildLayers(box *Box, items []*Item) []*Item {
+	var (
+		groups   [][]*Item
+		patterns []layerPattern
+	)
+
+	index := make(map[itemKind]int)
+
+	for _, it := range items {
+		kind := kindOfItem(it)
+
+		g, ok := index[kind]
+		if !ok {
+			g = len(groups)
+			index[kind] = g
+			groups = append(groups, nil)
+			patterns = append(patterns, bestLayerPattern(it, box.width, box.depth))
+		}
+
+		groups[g] = append(groups[g], it)
+	}
+
+	placed := make(map[*Item]bool)
+	// Groups whose layer did not fit at the current level, e.g. because of the weight limit.
+	failed := make(map[int]bool)
+
+	for {
+		base := box.GetUsedLength()
+		bestGroup := -1
+
+		for g, group := range groups {
+			p := patterns[g]
+
+			if failed[g] || len(p.slots) == 0 || len(group) < len(p.slots) || base+p.height > box.height+pointTolerance {
+				continue
+			}
+
+			if bestGroup < 0 || p.better(patterns[bestGroup]) {
+				bestGroup = g
+			}
+		}
+
+		if bestGroup < 0 {
+			break
+		}
+
+		n := len(patterns[bestGroup].slots)
+		if !placeLayer(box, groups[bestGroup][:n], patterns[bestGroup], base) {
+			failed[bestGroup] = true
+
+			continue
+		}
+
+		for _, it := range groups[bestGroup][:n] {
+			placed[it] = true
+		}
+
+		groups[bestGroup] = groups[bestGroup][n:]
+		clear(failed)
+	}
+
+	rest := make([]*Item, 0, len(items)-len(placed))
+
+	for _, it := range items {
+		if !placed[it] {
+			rest = append(rest, it)
+		}
+	}
+
+	return rest
+}
+
+// placeLayer puts the items into the slots of the pattern at the given height.
+// When one of them does not fit, the box is restored and false is returned.
+func placeLayer(box *Box, items []*Item, p layerPattern, base float64) bool {
+	snapshot := CopyPtr(box)
+
+	for k, slot := range p.slots {
+		if !putWithRotation(box, items[k], Pivot{slot.x, base, slot.z}, slot.rotation) {
+			*box = *snapshot
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// mixItems puts the items that do not form full layers into the box, largest first,
+// and returns the items left over.
+func mixItems(box *Box, items []*Item) []*Item {
+	sorted := append([]*Item(nil), items...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].volume > sorted[b].volume
+	})
+
+	rest := items[:0:0]
+
+	for _, it := range sorted {
+		if !fitInSpecificBox(box, it) {
+			rest = append(rest, it)
+		}
+	}
+
+	return rest
+}
+
+// layerSlot is the position of an item in a layer, on the width × depth face of a box.
+type layerSlot struct {
+	x, z     float64
+	rotation RotationType
+}
+
+// layerPattern is a single layer of identical items.
+type layerPattern struct {
+	height float64
+	slots  []layerSlot
+	// coverage is the share of the footprint covered by the items.
+	coverage float64
+}
+
+// better prefers the pattern covering more of the footprint, then the lower one.
+func (p layerPattern) better(o layerPattern) bool {
+	if p.coverage > o.coverage+epsilon {
+		return true
+	}
+
+	return p.coverage > o.coverage-epsilon && p.height < o.height
+}
+
+// layerOrientation is an allowed rotation of an item with its rotated sides.
+type layerOrientation struct {
+	rotation RotationType
+	w, h, d  float64
+}
+
+// bestLayerPattern returns the best two-block layer of the item on a width × depth footprint,
+// centred on the footprint. The pattern has no slots when the item does not fit the footprint.
+func bestLayerPattern(item *Item, width, depth float64) layerPattern {
+	orientations := make([]layerOrientation, 0, len(rotationMatrix))
+
+	for rt := RotationTypeWhd; rt <= RotationTypeWdh; rt++ {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
+		matrix := rotationMatrix[rt]
+		orientations = append(orientations, layerOrientation{
+			rotation: rt,
+			w:        item.whd[matrix[WidthAxis]],
+			h:        item.whd[matrix[HeightAxis]],
+			d:        item.whd[matrix[DepthAxis]],
+		})
+	}
+
+	var best layerPattern
+
+	for _, a := range orientations {
+		for _, b := range orientations {
+			if a.h != b.h {
+				continue
+			}
+
+			for _, alongWidth := range []bool{false, true} {
+				if p := twoBlockPattern(a, b, width, depth, alongWidth); len(p.slots) > 0 &&
+					(len(best.slots) == 0 || p.better(best)) {
+					best = p
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// twoBlockPattern splits the footprint into a block of orientation a and a block of orientation b behind it,
+// along the depth or along the width, at the split that holds the most items.
+func twoBlockPattern(a, b layerOrientation, width, depth float64, alongWidth bool) layerPattern {
+	// u is the axis the footprint is split along, v the other one.
+	spanU, spanV := depth, width
+	sides := func(o layerOrientation) (float64, float64) { return o.d, o.w }
+
+	if alongWidth {
+		spanU, spanV = width, depth
+		sides = func(o layerOrientation) (float64, float64) { return o.w, o.d }
+	}
+
+	au, av := sides(a)
+	bu, bv := sides(b)
+
+	if au <= 0 || av <= 0 || bu <= 0 || bv <= 0 {
+		return layerPattern{}
+	}
+
+	bestK, bestCount := 0, 0
+
+	for k := 0; float64(k)*au <= spanU+pointTolerance; k++ {
+		count := k*fitCount(spanV, av) + fitCount(spanU-float64(k)*au, bu)*fitCount(spanV, bv)
+		if count > bestCount {
+			bestK, bestCount = k, count
+		}
+	}
+
+	if bestCount == 0 {
+		return layerPattern{}
+	}
+
+	slots := make([]layerSlot, 0, bestCount)
+	extentU, extentV := 0.0, 0.0
+
+	addBlock := func(o layerOrientation, startU, ou, ov float64, rows int) {
+		cols := fitCount(spanV, ov)
+
+		for i := range rows {
+			for j := range cols {
+				u, v := startU+float64(i)*ou, float64(j)*ov
+
+				slot := layerSlot{x: v, z: u, rotation: o.rotation}
+				if alongWidth {
+					slot.x, slot.z = u, v
+				}
+
+				slots = append(slots, slot)
+				extentU, extentV = max(extentU, u+ou), max(extentV, v+ov)
+			}
+		}
+	}
+
+	addBlock(a, 0, au, av, bestK)
+	addBlock(b, float64(bestK)*au, bu, bv, fitCount(spanU-float64(bestK)*au, bu))
+
+	// Centre the layer on the footprint.
+	offsetU, offsetV := max(spanU-extentU, 0)/2, max(spanV-extentV, 0)/2 //nolint:mnd // half of the free space on each side
+	offsetX, offsetZ := offsetV, offsetU
+
+	if alongWidth {
+		offsetX, offsetZ = offsetU, offsetV
+	}
+
+	for k := range slots {
+		slots[k].x += offsetX
+		slots[k].z += offsetZ
+	}
+
+	return layerPattern{
+		height:   a.h,
+		slots:    slots,
+		coverage: float64(bestCount) * a.w * a.d / (width * depth),
+	}
+}
+
+// fitCount returns how many items of the given size fit in a row along the span.
+func fitCount(span, size float64) int {
+	if span < size-pointTolerance {
+		return 0
+	}
+
+	return int((span + pointTolerance) / size)
+}
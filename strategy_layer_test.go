@@ -0,0 +1,226 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestNewPallet tests the pallet footprint, load height and overhang.
+func TestNewPallet(t *testing.T) {
+	t.Parallel()
+
+	pallet := boxpacker3.NewPallet("euro", 1200, 800, 1500, 1000, boxpacker3.WithOverhang(25))
+
+	require.True(t, pallet.IsPallet())
+	require.False(t, boxpacker3.NewBox("box", 1, 1, 1, 1).IsPallet())
+
+	box := boxpacker3.NewBox("box", 100, 100, 100, 1000, boxpacker3.WithOverhang(25))
+	require.Zero(t, box.GetOverhang(), "Only pallets have an overhang")
+	require.InDelta(t, 100.0, box.GetWidth(), 0.0001)
+	require.InDelta(t, 100.0, box.GetDepth(), 0.0001)
+	require.InDelta(t, 25.0, pallet.GetOverhang(), 0.0001)
+	require.InDelta(t, 1250.0, pallet.GetWidth(), 0.0001)
+	require.InDelta(t, 1500.0, pallet.GetHeight(), 0.0001)
+	require.InDelta(t, 850.0, pallet.GetDepth(), 0.0001)
+	require.InDelta(t, 1250.0*1500*850, pallet.GetVolume(), 0.0001)
+
+	width, depth := pallet.GetFootprint()
+	require.InDelta(t, 1200.0, width, 0.0001)
+	require.InDelta(t, 800.0, depth, 0.0001)
+
+	data, err := json.Marshal(pallet)
+	require.NoError(t, err)
+
+	var restored boxpacker3.Box
+	require.NoError(t, json.Unmarshal(data, &restored))
+	require.True(t, restored.IsPallet())
+	require.InDelta(t, 1250.0, restored.GetWidth(), 0.0001)
+
+	width, depth = restored.GetFootprint()
+	require.InDelta(t, 1200.0, width, 0.0001)
+	require.InDelta(t, 800.0, depth, 0.0001)
+}
+
+// TestLayerStrategy_FullLayers tests that homogeneous cartons are stacked in full layers up to the load height.
+func TestLayerStrategy_FullLayers(t *testing.T) {
+	t.Parallel()
+
+	pallet := boxpacker3.NewPallet("euro", 1200, 800, 1000, 1000000)
+	items := make([]*boxpacker3.Item, 0, 40)
+
+	for i := range 40 {
+		items = append(items, boxpacker3.NewItem("carton-"+strconv.Itoa(i), 400, 200, 300, 1000))
+	}
+
+	boxes := []*boxpacker3.Box{pallet}
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewLayerStrategy()))
+
+	result, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Len(t, result.Boxes, 1)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	levels := make(map[float64]int)
+	for _, it := range result.Boxes[0].GetItems() {
+		levels[it.GetPosition()[boxpacker3.HeightAxis]]++
+		require.InDelta(t, 200.0, it.GetDimension()[boxpacker3.HeightAxis], 0.0001)
+	}
+
+	require.Equal(t, map[float64]int{0: 8, 200: 8, 400: 8, 600: 8, 800: 8}, levels)
+	require.InDelta(t, 1000.0, result.Boxes[0].GetUsedLength(), 0.0001)
+}
+
+// TestLayerStrategy_TwoBlock tests a layer mixing two orientations of the carton, which a plain grid cannot hold.
+func TestLayerStrategy_TwoBlock(t *testing.T) {
+	t.Parallel()
+
+	pallet := boxpacker3.NewPallet("pallet", 1100, 700, 200, 1000000)
+	items := make([]*boxpacker3.Item, 0, 5)
+
+	for i := range 5 {
+		items = append(items, boxpacker3.NewItem("carton-"+strconv.Itoa(i), 400, 200, 300, 1000,
+			boxpacker3.WithAllowedRotations(boxpacker3.RotationTypeWhd, boxpacker3.RotationTypeDhw)))
+	}
+
+	boxes := []*boxpacker3.Box{pallet}
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewLayerStrategy()))
+
+	result, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	rotations := make(map[boxpacker3.RotationType]int)
+	for _, it := range result.Boxes[0].GetItems() {
+		rotations[it.GetRotationType()]++
+	}
+
+	require.Len(t, rotations, 2)
+}
+
+// TestLayerStrategy_Mixed tests that full layers of each group come first and the remainder is mixed on top.
+func TestLayerStrategy_Mixed(t *testing.T) {
+	t.Parallel()
+
+	pallet := boxpacker3.NewPallet("euro", 1200, 800, 1800, 1000000, boxpacker3.WithMinSupport(0.75))
+	items := make([]*boxpacker3.Item, 0, 40)
+
+	for i := range 20 {
+		items = append(items, boxpacker3.NewItem("small-"+strconv.Itoa(i), 400, 200, 300, 1000))
+	}
+
+	for i := range 14 {
+		items = append(items, boxpacker3.NewItem("large-"+strconv.Itoa(i), 600, 250, 400, 3000))
+	}
+
+	for i := range 6 {
+		items = append(items, boxpacker3.NewItem("loose-"+strconv.Itoa(i), 100+float64(i)*20, 100, 150, 200))
+	}
+
+	boxes := []*boxpacker3.Box{pallet}
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewLayerStrategy()))
+
+	result, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	// Full layers: 2 of 8 small cartons and 3 of 4 large ones, followed by the mixed rest.
+	groups := make(map[float64]map[string]int)
+
+	for _, it := range result.Boxes[0].GetItems()[:28] {
+		y := it.GetPosition()[boxpacker3.HeightAxis]
+		if groups[y] == nil {
+			groups[y] = make(map[string]int)
+		}
+
+		groups[y][strings.Split(it.GetID(), "-")[0]]++
+	}
+
+	require.Len(t, groups, 5)
+
+	for _, layer := range groups {
+		require.Len(t, layer, 1, "Every full layer is homogeneous")
+	}
+}
+
+// TestLayerStrategy_Canceled tests that a canceled context stops the strategy.
+func TestLayerStrategy_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := boxpacker3.NewLayerStrategy().Pack(ctx,
+		[]*boxpacker3.Box{boxpacker3.NewPallet("euro", 1200, 800, 1000, 1000)},
+		[]*boxpacker3.Item{boxpacker3.NewItem("carton", 400, 200, 300, 10)})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestLayerStrategy_Overhang tests that the overhang lets a layer hold more cartons than the footprint alone.
+func TestLayerStrategy_Overhang(t *testing.T) {
+	t.Parallel()
+
+	items := make([]*boxpacker3.Item, 0, 6)
+	for i := range 6 {
+		items = append(items, boxpacker3.NewItem("carton-"+strconv.Itoa(i), 410, 200, 410, 1000))
+	}
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(boxpacker3.NewLayerStrategy()))
+
+	result, err := packer.PackCtx(context.Background(),
+		[]*boxpacker3.Box{boxpacker3.NewPallet("euro", 1200, 800, 200, 1000000)}, items)
+	require.NoError(t, err)
+	require.Len(t, result.UnfitItems, 4)
+
+	pallet := boxpacker3.NewPallet("euro", 1200, 800, 200, 1000000, boxpacker3.WithOverhang(20))
+
+	result, err = packer.PackCtx(context.Background(), []*boxpacker3.Box{pallet}, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult([]*boxpacker3.Box{pallet}, items, result))
+}
+
+// TestPallet_OverhangDeck tests that items stand over the deck of a pallet and only stick out into the overhang.
+func TestPallet_OverhangDeck(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{
+		boxpacker3.NewPallet("p", 100, 100, 100, 1000, boxpacker3.WithOverhang(10), boxpacker3.WithMinSupport(1)),
+	}
+	items := []*boxpacker3.Item{boxpacker3.NewItem("carton", 5, 5, 5, 1)}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+	require.Equal(t, boxpacker3.Pivot{10, 0, 10}, result.Boxes[0].GetItems()[0].GetPosition())
+
+	pallet := boxpacker3.NewPallet("p", 100, 100, 100, 1000, boxpacker3.WithOverhang(10))
+	require.False(t, pallet.PutItem(boxpacker3.NewItem("aside", 5, 5, 5, 1), boxpacker3.Pivot{}),
+		"The carton would stand in the overhang")
+
+	wide := boxpacker3.NewItem("wide", 20, 5, 20, 1)
+	require.True(t, pallet.PutItem(wide, boxpacker3.Pivot{}))
+	require.InDelta(t, 0.25, pallet.GetSupportRatio(wide), 1e-9, "Only a quarter of the base is over the deck")
+
+	// A carton moved into the overhang after packing is reported.
+	carton := boxpacker3.NewItem("carton", 5, 5, 5, 1)
+	forged := boxpacker3.NewPallet("p", 100, 100, 100, 1000)
+	require.True(t, forged.PutItem(carton, boxpacker3.Pivot{}))
+	boxpacker3.WithOverhang(10)(forged)
+
+	violations := boxpacker3.ValidateResult([]*boxpacker3.Box{forged}, []*boxpacker3.Item{carton},
+		&boxpacker3.Result{Boxes: []*boxpacker3.Box{forged}})
+	require.Len(t, violations, 1)
+	require.Equal(t, boxpacker3.ViolationOverhang, violations[0].Type)
+	require.Equal(t, "Overhang", boxpacker3.ViolationOverhang.String())
+}
@@ -0,0 +1,397 @@
+package boxpacker3
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+const (
+	defaultMaxIterations      = 1000
+	defaultInitialTemperature = 0.05
+)
+
+// moveKind is a neighbourhood move of the LocalSearchStrategy.
+type moveKind int
+
+const (
+	moveSwap moveKind = iota
+	moveReinsert
+	moveEmptyBox
+
+	moveKinds
+)
+
+// LocalSearchStrategy improves the result of another strategy with simulated annealing.
+//
+// The inner strategy builds the initial packing. The search then repeatedly applies one of
+// three moves to a copy of the current packing:
+//   - swap two items between boxes;
+//   - re-insert a packed or unfit item into another box;
+//   - empty the least-filled box by moving its items into the other boxes.
+//
+// A move is accepted when the goal does not rank it below the current packing. Worse moves are
+// accepted with a probability that starts at the initial temperature and cools down to zero,
+// which lets the search leave local optima. The best packing seen is returned when the iteration
+// limit is reached or the context is done, so the time budget is set with a context deadline.
+type LocalSearchStrategy struct {
+	inner         PackingAlgorithm
+	goal          ComparatorFunc
+	maxIterations int
+	temperature   float64
+	seed          uint64
+}
+
+// LocalSearchOption defines functional options for configuring the LocalSearchStrategy.
+type LocalSearchOption func(*LocalSearchStrategy)
+
+// WithLocalSearchGoal sets the comparator used to accept moves (MinimizeBoxesGoal by default).
+func WithLocalSearchGoal(goal ComparatorFunc) LocalSearchOption {
+	return func(s *LocalSearchStrategy) {
+		s.goal = goal
+	}
+}
+
+// WithMaxIterations sets the maximum number of moves (1000 by default).
+// With 0 the search only stops when the context is done, so the context must have a deadline.
+func WithMaxIterations(iterations int) LocalSearchOption {
+	return func(s *LocalSearchStrategy) {
+		s.maxIterations = max(iterations, 0)
+	}
+}
+
+// WithInitialTemperature sets the probability (0..1) of accepting a worse move at the start
+// of the search (0.05 by default). With 0 the search is a plain hill climb.
+func WithInitialTemperature(temperature float64) LocalSearchOption {
+	return func(s *LocalSearchStrategy) {
+		s.temperature = min(max(temperature, 0), 1)
+	}
+}
+
+// WithLocalSearchSeed sets the seed of the random number generator, so runs can be reproduced.
+func WithLocalSearchSeed(seed uint64) LocalSearchOption {
+	return func(s *LocalSearchStrategy) {
+		s.seed = seed
+	}
+}
+
+// NewLocalSearchStrategy creates a local search pass over the results of the inner strategy.
+//
+// Usage:
+//
+//	strategy := NewLocalSearchStrategy(
+//	    NewBestFitDecreasingStrategy(),
+//	    WithLocalSearchGoal(TightestPackingGoal),
+//	    WithMaxIterations(0),
+//	)
+//
+//	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+//	defer cancel()
+//
+//	result, err := strategy.Pack(ctx, boxes, items)
+func NewLocalSearchStrategy(inner PackingAlgorithm, opts ...LocalSearchOption) *LocalSearchStrategy {
+	s := &LocalSearchStrategy{
+		inner:         inner,
+		goal:          MinimizeBoxesGoal,
+		maxIterations: defaultMaxIterations,
+		temperature:   defaultInitialTemperature,
+		seed:          1,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the identifier for this strategy.
+func (s *LocalSearchStrategy) Name() string {
+	return "LocalSearch(" + s.inner.Name() + ")"
+}
+
+// Pack runs the inner strategy and improves its result until the iteration limit
+// is reached or the context is done.
+func (s *LocalSearchStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	current, err := s.inner.Pack(ctx, boxes, items)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(s.seed, s.seed)) //nolint:gosec // reproducibility matters, not security
+	best := current
+	start := time.Now()
+	deadline, hasDeadline := ctx.Deadline()
+
+	for iteration := 0; s.maxIterations == 0 || iteration < s.maxIterations; iteration++ {
+		if checkContext(ctx) != nil {
+			break
+		}
+
+		candidate := cloneResult(current)
+		if !applyMove(rng, candidate, moveKind(rng.IntN(int(moveKinds)))) {
+			continue
+		}
+
+		var progress float64
+
+		switch {
+		case s.maxIterations > 0:
+			progress = float64(iteration) / float64(s.maxIterations)
+		case hasDeadline:
+			progress = float64(time.Since(start)) / float64(deadline.Sub(start))
+		}
+
+		if !s.goal(current, candidate) || rng.Float64() < s.temperature*(1-progress) {
+			current = candidate
+		}
+
+		if s.goal(current, best) {
+			best = current
+		}
+	}
+
+	best.Boxes = dropEmptiedBoxes(best.Boxes)
+
+	return best, nil
+}
+
+// cloneResult copies the result together with its boxes and items, so moves do not change the original.
+// Optimal is not copied: it only holds for the result of the inner strategy as is.
+func cloneResult(r *Result) *Result {
+	result := &Result{
+		Boxes:      make(boxSlice, 0, len(r.Boxes)),
+		UnfitItems: CopySlicePtr(r.UnfitItems),
+		Unfinished: append([]string(nil), r.Unfinished...),
+	}
+
+	for _, b := range r.Boxes {
+		box := CopyPtr(b)
+		if box != nil {
+			box.items = CopySlicePtr(b.items)
+		}
+
+		result.Boxes = append(result.Boxes, box)
+	}
+
+	return result
+}
+
+// applyMove applies a random move of the given kind to the result in place.
+// It reports false when the move is not possible; the result must be discarded then.
+func applyMove(rng *rand.Rand, r *Result, kind moveKind) bool {
+	used := make([]int, 0, len(r.Boxes))
+
+	for i, b := range r.Boxes {
+		if b != nil && len(b.items) > 0 {
+			used = append(used, i)
+		}
+	}
+
+	switch kind {
+	case moveSwap:
+		return swapItems(rng, r, used)
+	case moveReinsert:
+		return reinsertItem(rng, r, used)
+	case moveEmptyBox:
+		return emptyLeastFilled(r, used)
+	case moveKinds:
+	}
+
+	return false
+}
+
+// swapItems exchanges two random items of two random used boxes.
+func swapItems(rng *rand.Rand, r *Result, used []int) bool {
+	if len(used) < 2 { //nolint:mnd
+		return false
+	}
+
+	i, j := used[rng.IntN(len(used))], used[rng.IntN(len(used))]
+	if i == j {
+		return false
+	}
+
+	a, b := r.Boxes[i], r.Boxes[j]
+	itemA, itemB := a.items[rng.IntN(len(a.items))], b.items[rng.IntN(len(b.items))]
+
+	return removeItem(a, itemA) && removeItem(b, itemB) && packInto(a, itemB) && packInto(b, itemA)
+}
+
+// reinsertItem moves a random packed or unfit item into another random box.
+func reinsertItem(rng *rand.Rand, r *Result, used []int) bool {
+	packed := 0
+	for _, i := range used {
+		packed += len(r.Boxes[i].items)
+	}
+
+	if packed+len(r.UnfitItems) == 0 || len(r.Boxes) == 0 {
+		return false
+	}
+
+	target := r.Boxes[rng.IntN(len(r.Boxes))]
+	if target == nil {
+		return false
+	}
+
+	k := rng.IntN(packed + len(r.UnfitItems))
+	if k >= packed {
+		k -= packed
+		item := r.UnfitItems[k]
+		r.UnfitItems = append(r.UnfitItems[:k:k], r.UnfitItems[k+1:]...)
+
+		return packInto(target, item)
+	}
+
+	for _, i := range used {
+		source := r.Boxes[i]
+		if k >= len(source.items) {
+			k -= len(source.items)
+
+			continue
+		}
+
+		if source == target {
+			return false
+		}
+
+		item := source.items[k]
+
+		return removeItem(source, item) && packInto(target, item)
+	}
+
+	return false
+}
+
+// emptyLeastFilled moves every item of the used box with the lowest fill rate into the other boxes.
+func emptyLeastFilled(r *Result, used []int) bool {
+	if len(used) < 2 { //nolint:mnd
+		return false
+	}
+
+	sort.SliceStable(used, func(a, b int) bool {
+		return r.Boxes[used[a]].GetFillRate() > r.Boxes[used[b]].GetFillRate()
+	})
+
+	source := r.Boxes[used[len(used)-1]]
+	items := CopySlicePtr(source.items)
+	source.Reset()
+
+	sort.SliceStable(items, func(a, b int) bool {
+		return items[a].volume > items[b].volume
+	})
+
+	for _, item := range items {
+		placed := false
+
+		for _, i := range used[:len(used)-1] {
+			if placed = packInto(r.Boxes[i], item); placed {
+				break
+			}
+		}
+
+		if !placed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeItem takes the item out of the box. The other items keep their positions and rotations
+// where they still can, e.g. unless they lose their support, otherwise they are placed again.
+func removeItem(box *Box, item *Item) bool {
+	rest := make([]*Item, 0, len(box.items))
+
+	for _, it := range box.items {
+		if it != item {
+			rest = append(rest, it)
+		}
+	}
+
+	return repack(box, rest, true)
+}
+
+// packInto puts the item into the box at an extreme point, or repacks the whole box
+// in decreasing volume when there is no room left at the extreme points.
+func packInto(box *Box, item *Item) bool {
+	if fitInSpecificBox(box, item) {
+		return true
+	}
+
+	items := append(CopySlicePtr(box.items), item)
+	sort.SliceStable(items, func(a, b int) bool {
+		return items[a].volume > items[b].volume
+	})
+
+	return repack(box, items, false)
+}
+
+// repack empties the box and places the items again, in the given order. With keep set,
+// every item is tried at its current position and rotation first. When an item does not fit,
+// the box is restored from a snapshot of its previous contents and false is returned.
+func repack(box *Box, items []*Item, keep bool) bool {
+	snapshot := CopyPtr(box)
+	snapshot.items = CopySlicePtr(box.items)
+
+	placements := make(map[*Item]placement, len(items))
+	for _, it := range items {
+		placements[it] = placement{position: it.position, rotation: it.rotationType}
+	}
+
+	box.Reset()
+
+	for _, it := range items {
+		p := placements[it]
+		if keep && putWithRotation(box, it, p.position, p.rotation) {
+			continue
+		}
+
+		if !fitInSpecificBox(box, it) {
+			*box = *snapshot
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// putWithRotation puts the item at the pivot in the given rotation only.
+// It fails when the item does not allow the rotation.
+func putWithRotation(box *Box, item *Item, pv Pivot, rt RotationType) bool {
+	if !item.allowsRotation(rt) {
+		return false
+	}
+
+	allowed := item.rotations
+	item.rotations = 1 << rt
+	placed := box.PutItem(item, pv)
+	item.rotations = allowed
+
+	return placed
+}
+
+// dropEmptiedBoxes removes BoxType instances that lost all their items during the search,
+// keeping one empty instance per type as the built-in strategies do.
+func dropEmptiedBoxes(boxes boxSlice) boxSlice {
+	result := make(boxSlice, 0, len(boxes))
+	spare := make(map[*BoxType]bool)
+
+	for _, b := range boxes {
+		if b == nil || b.boxType == nil || len(b.items) > 0 {
+			result = append(result, b)
+
+			continue
+		}
+
+		if !spare[b.boxType] {
+			spare[b.boxType] = true
+
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,77 @@
+package boxpacker3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmptyLeastFilled tests that the move empties the least filled box into the other boxes.
+func TestEmptyLeastFilled(t *testing.T) {
+	t.Parallel()
+
+	full := makeBoxWithItems("full", 10, 10, 10, 100, makeItem("large", 6, 10, 10, 1))
+	light := makeBoxWithItems("light", 10, 10, 10, 100, makeItem("small", 4, 10, 10, 1))
+	result := &Result{Boxes: boxSlice{full, light}}
+
+	require.True(t, emptyLeastFilled(result, []int{0, 1}))
+	require.Empty(t, light.items)
+	require.Len(t, full.items, 2)
+	require.InDelta(t, 1.0, full.GetFillRate(), 1e-9)
+
+	tooLarge := makeBoxWithItems("other", 10, 10, 10, 100, makeItem("large", 6, 10, 10, 1))
+	result = &Result{Boxes: boxSlice{makeBoxWithItems("full", 10, 10, 10, 100, makeItem("large", 6, 10, 10, 1)), tooLarge}}
+
+	require.False(t, emptyLeastFilled(result, []int{0, 1}), "The items of the emptied box do not fit elsewhere")
+	require.False(t, emptyLeastFilled(result, []int{0}), "A single box cannot be emptied")
+}
+
+// TestCloneResult tests that the copy keeps the unfinished algorithms but not the optimality flag.
+func TestCloneResult(t *testing.T) {
+	t.Parallel()
+
+	box := makeBoxWithItems("box", 10, 10, 10, 100, makeItem("item", 1, 1, 1, 1))
+	original := &Result{Boxes: boxSlice{box}, Optimal: true, Unfinished: []string{"Genetic"}}
+
+	clone := cloneResult(original)
+	require.Equal(t, []string{"Genetic"}, clone.Unfinished)
+	require.False(t, clone.Optimal)
+	require.NotSame(t, box, clone.Boxes[0])
+	require.NotSame(t, box.items[0], clone.Boxes[0].items[0])
+
+	clone.Unfinished[0] = "BranchAndBound"
+	require.Equal(t, []string{"Genetic"}, original.Unfinished)
+}
+
+// TestPutWithRotation tests that an item is only put in a rotation it allows.
+func TestPutWithRotation(t *testing.T) {
+	t.Parallel()
+
+	box := NewBox("box", 10, 10, 10, 100)
+	item := NewItem("upright", 2, 4, 6, 1, WithThisSideUp())
+
+	require.False(t, putWithRotation(box, item, Pivot{}, RotationTypeHwd), "The item must stay upright")
+	require.Empty(t, box.items)
+
+	require.True(t, putWithRotation(box, item, Pivot{}, RotationTypeDhw))
+	require.Equal(t, RotationTypeDhw, item.GetRotationType())
+}
+
+// TestRepack_Restore tests that a failed repack restores the box even when its items
+// cannot be placed again in their stored order.
+func TestRepack_Restore(t *testing.T) {
+	t.Parallel()
+
+	top := makeItem("top", 10, 5, 10, 1)
+	top.position = Pivot{0, 5, 0}
+	bottom := makeItem("bottom", 10, 5, 10, 1)
+
+	// The top item comes first, so it has no support when the items are put back one by one.
+	box := makeBoxWithItems("box", 10, 10, 10, 100, top, bottom)
+	WithMinSupport(1)(box)
+
+	require.False(t, packInto(box, makeItem("extra", 10, 10, 10, 1)))
+	require.Len(t, box.items, 2)
+	require.Equal(t, Pivot{0, 5, 0}, box.items[0].position)
+	require.Equal(t, Pivot{}, box.items[1].position)
+}
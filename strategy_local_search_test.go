@@ -0,0 +1,94 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+func localSearchFixture() ([]*boxpacker3.Box, []*boxpacker3.Item) {
+	return seededFixture(boxpacker3.NewBox("carton", 100, 100, 100, 1000), 4, 30, 10, 60)
+}
+
+// TestLocalSearchStrategy_Improves tests that the local search saves boxes over its inner strategy.
+func TestLocalSearchStrategy_Improves(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := localSearchFixture()
+
+	base, err := boxpacker3.NewNextFitStrategy().Pack(context.Background(), boxes, items)
+	require.NoError(t, err)
+
+	strategy := boxpacker3.NewLocalSearchStrategy(boxpacker3.NewNextFitStrategy(),
+		boxpacker3.WithMaxIterations(300),
+		boxpacker3.WithLocalSearchSeed(3),
+	)
+	require.Equal(t, "LocalSearch(NextFit)", strategy.Name())
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy))
+
+	first, err := packer.PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, first.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, first))
+	require.Less(t, boxpacker3.BoxCountMetric(first), boxpacker3.BoxCountMetric(base))
+
+	requireReproducible(t, packer, boxes, items)
+}
+
+// TestLocalSearchStrategy_UnfitItems tests that unfit items are re-inserted once there is room.
+func TestLocalSearchStrategy_UnfitItems(t *testing.T) {
+	t.Parallel()
+
+	// NextFit moves on to the second box for the second large item and never comes back to the first one.
+	boxes := []*boxpacker3.Box{
+		boxpacker3.NewBox("a", 10, 10, 10, 100),
+		boxpacker3.NewBox("b", 10, 10, 10, 100),
+	}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("large", 10, 10, 6, 1),
+		boxpacker3.NewItem("large", 10, 10, 6, 1),
+		boxpacker3.NewItem("slab", 10, 10, 4, 1),
+		boxpacker3.NewItem("slab", 10, 10, 4, 1),
+	}
+
+	base, err := boxpacker3.NewNextFitStrategy().Pack(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Len(t, base.UnfitItems, 1)
+
+	result, err := boxpacker3.NewLocalSearchStrategy(boxpacker3.NewNextFitStrategy(),
+		boxpacker3.WithMaxIterations(1000),
+		boxpacker3.WithInitialTemperature(0),
+	).Pack(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+}
+
+// TestLocalSearchStrategy_Deadline tests that the search stops at the context deadline and keeps its result.
+func TestLocalSearchStrategy_Deadline(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := localSearchFixture()
+	strategy := boxpacker3.NewLocalSearchStrategy(boxpacker3.NewBestFitDecreasingStrategy(),
+		boxpacker3.WithMaxIterations(0),
+		boxpacker3.WithLocalSearchGoal(boxpacker3.TightestPackingGoal),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	result, err := strategy.Pack(ctx, boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strategy.Pack(canceled, boxes, items)
+	require.ErrorIs(t, err, context.Canceled)
+}
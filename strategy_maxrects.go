@@ -0,0 +1,351 @@
+package boxpacker3
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MaxRectsHeuristic selects the free rectangle an item is placed into by the MaxRectsStrategy.
+type MaxRectsHeuristic int
+
+const (
+	// MaxRectsBestShortSideFit places the item where the shorter leftover side of the free rectangle
+	// is the smallest. It is the best general purpose choice.
+	MaxRectsBestShortSideFit MaxRectsHeuristic = iota
+	// MaxRectsBestAreaFit places the item into the smallest free rectangle it fits.
+	MaxRectsBestAreaFit
+	// MaxRectsBottomLeft places the item as low as possible, then as far left as possible (Tetris style).
+	MaxRectsBottomLeft
+)
+
+// String returns the name of the heuristic.
+func (h MaxRectsHeuristic) String() string {
+	switch h {
+	case MaxRectsBestShortSideFit:
+		return "BSSF"
+	case MaxRectsBestAreaFit:
+		return "BAF"
+	case MaxRectsBottomLeft:
+		return "BL"
+	default:
+		return fmt.Sprintf("MaxRectsHeuristic(%d)", int(h))
+	}
+}
+
+// MaxRectsStrategy is a two-dimensional packing engine for sheets, boards and panels.
+//
+// Every box keeps the list of maximal free rectangles on its width × height face. Items are taken
+// in decreasing area and each one is put into the free rectangle chosen by the heuristic, either
+// as is or turned by 90° (RotationTypeHwd) when its allowed rotations permit. Boxes are filled
+// one after another, like the MinimizeBoxes strategy does. Placements that break the stability, load or
+// balance limits of a box (see WithMinSupport, WithMaxLoad and WithAxles) are skipped, as Box.PutItem does.
+//
+// Items are placed in a single layer at depth 0, so the result is a list of x/y placements;
+// see Box.GetPlacements2D. The Packer selects this strategy automatically when every box and
+// item is 2D (see NewBox2D and NewItem2D), none of the boxes is a strip (see NewStrip)
+// and no algorithm was set with WithAlgorithm.
+type MaxRectsStrategy struct {
+	heuristic MaxRectsHeuristic
+}
+
+// MaxRectsOption defines functional options for configuring the MaxRectsStrategy.
+type MaxRectsOption func(*MaxRectsStrategy)
+
+// WithMaxRectsHeuristic sets the placement heuristic (MaxRectsBestShortSideFit by default).
+func WithMaxRectsHeuristic(heuristic MaxRectsHeuristic) MaxRectsOption {
+	return func(s *MaxRectsStrategy) {
+		s.heuristic = heuristic
+	}
+}
+
+// NewMaxRectsStrategy creates a new 2D MaxRects strategy.
+func NewMaxRectsStrategy(opts ...MaxRectsOption) *MaxRectsStrategy {
+	s := &MaxRectsStrategy{
+		heuristic: MaxRectsBestShortSideFit,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the identifier for this strategy.
+func (s *MaxRectsStrategy) Name() string {
+	return "MaxRects"
+}
+
+// Pack places the items box by box, largest area first.
+func (s *MaxRectsStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	remaining := compactItems(items)
+	sort.SliceStable(remaining, func(a, b int) bool {
+		return remaining[a].whd[WidthAxis]*remaining[a].whd[HeightAxis] >
+			remaining[b].whd[WidthAxis]*remaining[b].whd[HeightAxis]
+	})
+
+	sortedBoxes, result := prepareData(boxes, remaining)
+
+	for i := 0; i < len(sortedBoxes) && len(remaining) > 0; i++ {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+
+		if sortedBoxes[i] == nil {
+			continue
+		}
+
+		sheet := newMaxRectsSheet(sortedBoxes[i])
+		rest := sheet.fill(remaining, s.heuristic)
+
+		remaining = rest
+		sortedBoxes = replenishBoxType(sortedBoxes, i)
+	}
+
+	result.UnfitItems = append(result.UnfitItems, remaining...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
+
+	return result, nil
+}
+
+// Placement2D is the position of an item on the width × height face of a box.
+type Placement2D struct {
+	Item *Item
+	// X and Y are the coordinates of the corner of the item closest to the origin.
+	X, Y float64
+	// Width and Height are the sides of the item as placed.
+	Width, Height float64
+	// Rotated is set when the item is turned by 90°, i.e. its width lies along the height of the box.
+	Rotated bool
+}
+
+// GetPlacements2D returns the x/y placements of the items in the box, in packing order.
+func (b *Box) GetPlacements2D() []Placement2D {
+	placements := make([]Placement2D, 0, len(b.items))
+
+	for _, it := range b.items {
+		dim := it.GetDimension()
+		placements = append(placements, Placement2D{
+			Item:    it,
+			X:       it.position[WidthAxis],
+			Y:       it.position[HeightAxis],
+			Width:   dim[WidthAxis],
+			Height:  dim[HeightAxis],
+			Rotated: it.rotationType != RotationTypeWhd,
+		})
+	}
+
+	return placements
+}
+
+// all2D reports whether there is at least one box and every box and item is 2D.
+func all2D(boxes []*Box, items []*Item) bool {
+	found := false
+
+	for _, b := range boxes {
+		if b == nil {
+			continue
+		}
+
+		if !b.Is2D() {
+			return false
+		}
+
+		found = true
+	}
+
+	for _, it := range items {
+		if it != nil && !it.Is2D() {
+			return false
+		}
+	}
+
+	return found
+}
+
+// rect is an axis-aligned rectangle on the width × height face of a box.
+type rect struct {
+	x, y, w, h float64
+}
+
+func (r rect) contains(o rect) bool {
+	return o.x >= r.x-pointTolerance && o.y >= r.y-pointTolerance &&
+		o.x+o.w <= r.x+r.w+pointTolerance && o.y+o.h <= r.y+r.h+pointTolerance
+}
+
+func (r rect) overlaps(o rect) bool {
+	return o.x < r.x+r.w-pointTolerance && r.x < o.x+o.w-pointTolerance &&
+		o.y < r.y+r.h-pointTolerance && r.y < o.y+o.h-pointTolerance
+}
+
+// maxRectsSheet tracks the maximal free rectangles of a box.
+type maxRectsSheet struct {
+	box  *Box
+	free []rect
+}
+
+func newMaxRectsSheet(box *Box) *maxRectsSheet {
+	sheet := &maxRectsSheet{
+		box:  box,
+		free: []rect{{w: box.width, h: box.height}},
+	}
+
+	for _, it := range box.items {
+		dim := it.GetDimension()
+		sheet.occupy(rect{it.position[WidthAxis], it.position[HeightAxis], dim[WidthAxis], dim[HeightAxis]})
+	}
+
+	return sheet
+}
+
+// fill repeatedly places the item with the best score of all remaining items and returns the items left over.
+// Ties keep the input order, so larger items go first. Bottom-left scores favour small items,
+// so with MaxRectsBottomLeft the items are placed in the input order instead.
+func (m *maxRectsSheet) fill(items []*Item, heuristic MaxRectsHeuristic) []*Item {
+	rest := append([]*Item(nil), items...)
+
+	for len(rest) > 0 {
+		bestIndex := -1
+
+		var best maxRectsCandidate
+
+		for k, item := range rest {
+			if c, ok := m.find(item, heuristic); ok && (bestIndex < 0 || c.better(best)) {
+				bestIndex, best = k, c
+
+				if heuristic == MaxRectsBottomLeft {
+					break
+				}
+			}
+		}
+
+		if bestIndex < 0 {
+			break
+		}
+
+		item := rest[bestIndex]
+		item.position = Pivot{best.rect.x, best.rect.y, 0}
+		item.setRotationType(best.rotation)
+		m.box.insert(item)
+		m.occupy(best.rect)
+
+		rest = append(rest[:bestIndex], rest[bestIndex+1:]...)
+	}
+
+	return rest
+}
+
+// maxRectsCandidate is a placement of an item into a free rectangle.
+type maxRectsCandidate struct {
+	rect     rect
+	rotation RotationType
+	score    [2]float64
+}
+
+func (c maxRectsCandidate) better(o maxRectsCandidate) bool {
+	return c.score[0] < o.score[0] || (c.score[0] == o.score[0] && c.score[1] < o.score[1])
+}
+
+// find returns the placement of the item with the best score, as is or turned by 90°.
+// Placements that break the stability, load or balance limits of the box are skipped.
+func (m *maxRectsSheet) find(item *Item, heuristic MaxRectsHeuristic) (maxRectsCandidate, bool) {
+	best := maxRectsCandidate{score: [2]float64{math.Inf(1), math.Inf(1)}}
+	found := false
+
+	if !m.box.canQuota(item) {
+		return best, false
+	}
+
+	for _, rt := range []RotationType{RotationTypeWhd, RotationTypeHwd} {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
+		matrix := rotationMatrix[rt]
+		w, h, d := item.whd[matrix[WidthAxis]], item.whd[matrix[HeightAxis]], item.whd[matrix[DepthAxis]]
+
+		if d > m.box.depth {
+			continue
+		}
+
+		for _, fr := range m.free {
+			if w > fr.w+pointTolerance || h > fr.h+pointTolerance {
+				continue
+			}
+
+			c := maxRectsCandidate{rect{fr.x, fr.y, w, h}, rt, heuristic.score(fr, w, h)}
+			if c.better(best) && m.box.meetsLimitsAt(item, Pivot{fr.x, fr.y, 0}, rt) {
+				best, found = c, true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// score ranks placing a w × h item into the free rectangle; lower is better.
+func (h MaxRectsHeuristic) score(fr rect, w, hgt float64) [2]float64 {
+	leftoverW, leftoverH := fr.w-w, fr.h-hgt
+
+	switch h {
+	case MaxRectsBestAreaFit:
+		return [2]float64{fr.w*fr.h - w*hgt, min(leftoverW, leftoverH)}
+	case MaxRectsBottomLeft:
+		return [2]float64{fr.y + hgt, fr.x}
+	case MaxRectsBestShortSideFit:
+	}
+
+	return [2]float64{min(leftoverW, leftoverH), max(leftoverW, leftoverH)}
+}
+
+// occupy splits every free rectangle that overlaps the used one into the maximal rectangles
+// around it and drops the free rectangles contained in others.
+func (m *maxRectsSheet) occupy(used rect) {
+	free := make([]rect, 0, len(m.free)+4) //nolint:mnd // up to four parts per split
+
+	for _, fr := range m.free {
+		if !fr.overlaps(used) {
+			free = append(free, fr)
+
+			continue
+		}
+
+		if used.x > fr.x+pointTolerance {
+			free = append(free, rect{fr.x, fr.y, used.x - fr.x, fr.h})
+		}
+
+		if right := fr.x + fr.w - (used.x + used.w); right > pointTolerance {
+			free = append(free, rect{used.x + used.w, fr.y, right, fr.h})
+		}
+
+		if used.y > fr.y+pointTolerance {
+			free = append(free, rect{fr.x, fr.y, fr.w, used.y - fr.y})
+		}
+
+		if top := fr.y + fr.h - (used.y + used.h); top > pointTolerance {
+			free = append(free, rect{fr.x, used.y + used.h, fr.w, top})
+		}
+	}
+
+	m.free = m.free[:0]
+
+	for i, fr := range free {
+		redundant := false
+
+		for j, other := range free {
+			// Of two equal rectangles, the first one is kept.
+			if i != j && other.contains(fr) && (!fr.contains(other) || j < i) {
+				redundant = true
+
+				break
+			}
+		}
+
+		if !redundant {
+			m.free = append(m.free, fr)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+func sheetFixture() ([]*boxpacker3.Box, []*boxpacker3.Item) {
+	return seededFixture(boxpacker3.NewBox2D("sheet", 100, 80, 1000), 1, 40, 5, 45)
+}
+
+// TestMaxRectsStrategy_Auto tests that the packer switches to MaxRects for 2D inputs.
+func TestMaxRectsStrategy_Auto(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := sheetFixture()
+
+	auto, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, auto.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, auto))
+
+	layered, err := boxpacker3.NewPacker(
+		boxpacker3.WithAlgorithm(boxpacker3.NewMinimizeBoxesStrategy()),
+	).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, layered.UnfitItems)
+
+	require.InDelta(t, 3.0, boxpacker3.BoxCountMetric(auto), 0)
+	require.Less(t, boxpacker3.BoxCountMetric(auto), boxpacker3.BoxCountMetric(layered))
+
+	for _, b := range auto.Boxes {
+		for _, p := range b.GetPlacements2D() {
+			require.GreaterOrEqual(t, p.X, 0.0)
+			require.GreaterOrEqual(t, p.Y, 0.0)
+			require.LessOrEqual(t, p.X+p.Width, b.GetWidth())
+			require.LessOrEqual(t, p.Y+p.Height, b.GetHeight())
+			require.InDelta(t, 0.0, p.Item.GetPosition()[boxpacker3.DepthAxis], 0)
+			require.Equal(t, p.Rotated, p.Item.GetRotationType() == boxpacker3.RotationTypeHwd)
+		}
+	}
+}
+
+// TestMaxRectsStrategy_Heuristics tests that every heuristic produces a valid layout.
+func TestMaxRectsStrategy_Heuristics(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := sheetFixture()
+
+	for _, h := range []boxpacker3.MaxRectsHeuristic{
+		boxpacker3.MaxRectsBestShortSideFit,
+		boxpacker3.MaxRectsBestAreaFit,
+		boxpacker3.MaxRectsBottomLeft,
+	} {
+		t.Run(h.String(), func(t *testing.T) {
+			t.Parallel()
+
+			strategy := boxpacker3.NewMaxRectsStrategy(boxpacker3.WithMaxRectsHeuristic(h))
+			require.Equal(t, "MaxRects", strategy.Name())
+
+			result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, items)
+			require.NoError(t, err)
+			require.Empty(t, result.UnfitItems)
+			require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+		})
+	}
+}
+
+// TestMaxRectsStrategy_Rotation tests the 90° rotation flag and rotation constraints.
+func TestMaxRectsStrategy_Rotation(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{boxpacker3.NewBox2D("strip", 30, 80, 100)}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("turnable", 60, 20, 1),
+		boxpacker3.NewItem2D("fixed", 60, 20, 1, boxpacker3.WithAllowedRotations(boxpacker3.RotationTypeWhd)),
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+
+	require.Len(t, result.UnfitItems, 1)
+	require.Equal(t, "fixed", result.UnfitItems[0].GetID())
+	require.Equal(t, boxpacker3.UnfitReasonTooLarge, result.UnfitItems[0].GetUnfitReason())
+
+	placements := result.Boxes[0].GetPlacements2D()
+	require.Len(t, placements, 1)
+	require.True(t, placements[0].Rotated)
+	require.InDelta(t, 20.0, placements[0].Width, 0)
+	require.InDelta(t, 60.0, placements[0].Height, 0)
+}
+
+// TestMaxRectsStrategy_Mixed tests that 3D inputs keep the configured strategy.
+func TestMaxRectsStrategy_Mixed(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox2D("sheet", 100, 80, 1000)
+	require.True(t, box.Is2D())
+	require.True(t, boxpacker3.NewItem2D("panel", 10, 10, 1).Is2D())
+	require.False(t, boxpacker3.NewItem("cube", 10, 10, 10, 1).Is2D())
+
+	// A 3D item on its side is still packed by the 3D engine, which may rotate it flat.
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(),
+		[]*boxpacker3.Box{box},
+		[]*boxpacker3.Item{boxpacker3.NewItem("board", 1, 40, 30, 1)})
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.InDelta(t, 1.0, result.Boxes[0].GetItems()[0].GetDimension()[boxpacker3.DepthAxis], 0)
+}
+
+// TestMaxRectsStrategy_Limits tests that the automatic 2D engine respects stability and load limits.
+func TestMaxRectsStrategy_Limits(t *testing.T) {
+	t.Parallel()
+
+	boxes := boxpacker3.BoxesFromTypes(
+		boxpacker3.NewBoxType(boxpacker3.NewBox2D("b", 10, 10, 1000), boxpacker3.UnlimitedQuantity),
+	)
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("glass", 10, 5, 1, boxpacker3.WithFragile()),
+		boxpacker3.NewItem2D("anvil", 10, 5, 50),
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result), "Nothing rests on the glass")
+
+	packer := boxpacker3.NewPacker(boxpacker3.WithStability(1))
+
+	for seed := range uint64(50) {
+		boxes, items := seededFixture(boxpacker3.NewBox2D("sheet", 100, 80, 1000), seed, 40, 5, 45)
+
+		result, err := packer.PackCtx(context.Background(), boxes, items)
+		require.NoError(t, err)
+		require.Empty(t, result.UnfitItems)
+		require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+	}
+}
@@ -10,6 +10,7 @@ import (
 type ParallelStrategy struct {
 	algorithms []PackingAlgorithm
 	goal       ComparatorFunc
+	bestEffort bool
 }
 
 // ParallelOption defines functional options for configuring the ParallelStrategy.
@@ -53,6 +54,15 @@ func WithGoal(goal ComparatorFunc) ParallelOption {
 	}
 }
 
+// WithBestEffort makes Pack return the best result received so far when the context is done,
+// instead of ctx.Err(). The names of the algorithms that did not finish in time are listed in
+// Result.Unfinished. ctx.Err() is still returned when no algorithm finished.
+func WithBestEffort() ParallelOption {
+	return func(p *ParallelStrategy) {
+		p.bestEffort = true
+	}
+}
+
 // Name returns the identifier for this strategy.
 func (s *ParallelStrategy) Name() string {
 	return "ParallelStrategy"
@@ -71,15 +81,20 @@ func (s *ParallelStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item
 		return &Result{UnfitItems: items, Boxes: []*Box{}}, nil
 	}
 
-	results := make(chan *Result, len(s.algorithms))
+	type outcome struct {
+		index  int
+		result *Result
+	}
+
+	results := make(chan outcome, len(s.algorithms))
 
 	var wg sync.WaitGroup
 
 	// Launch each algorithm in a separate goroutine
-	for _, algo := range s.algorithms {
+	for i, algo := range s.algorithms {
 		wg.Add(1)
 
-		go func(a PackingAlgorithm) {
+		go func(i int, a PackingAlgorithm) {
 			defer wg.Done()
 
 			// Check context before doing work
@@ -89,9 +104,9 @@ func (s *ParallelStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item
 
 			res, err := a.Pack(ctx, CopySlicePtr(boxes), CopySlicePtr(items))
 			if err == nil && res != nil {
-				results <- res
+				results <- outcome{index: i, result: res}
 			}
-		}(algo)
+		}(i, algo)
 	}
 
 	// Closer goroutine
@@ -103,17 +118,66 @@ func (s *ParallelStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item
 	// Select the best result
 	var bestResult *Result
 
-	// Read from channel until closed
-	for res := range results {
+	finished := make([]bool, len(s.algorithms))
+
+	// In best-effort mode stop waiting when the context is done;
+	// the channel is buffered, so the remaining goroutines never block.
+	var done <-chan struct{}
+	if s.bestEffort {
+		done = ctx.Done()
+	}
+
+	take := func(res outcome) {
+		finished[res.index] = true
+
 		// Check if the new result is better than what we have so far
-		if s.goal(res, bestResult) {
-			bestResult = res
+		if s.goal(res.result, bestResult) {
+			bestResult = res.result
+		}
+	}
+
+collect:
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				break collect
+			}
+
+			take(res)
+		case <-done:
+			// select picks a ready case at random, so results sent before the deadline may still be buffered.
+			for {
+				select {
+				case res, ok := <-results:
+					if !ok {
+						break collect
+					}
+
+					take(res)
+				default:
+					break collect
+				}
+			}
 		}
 	}
 
 	// If context was canceled or all strategies failed, handle gracefully
 	if ctx.Err() != nil {
-		return nil, ctx.Err()
+		if !s.bestEffort || bestResult == nil {
+			return nil, ctx.Err()
+		}
+
+		partial := *bestResult
+		partial.Unfinished = make([]string, 0, len(s.algorithms))
+
+		for i, algo := range s.algorithms {
+			if !finished[i] {
+				partial.Unfinished = append(partial.Unfinished, algo.Name())
+			}
+		}
+
+		return &partial, nil
 	}
 
 	// If no strategy produced a valid result (rare, but possible if all fail),
@@ -0,0 +1,225 @@
+package boxpacker3
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// SkylineStrategy is a 2D strip packing engine for rolls of fabric, paper or film.
+//
+// The free space of a box is described by its skyline: the top edge of the items placed so far,
+// seen from the open end of the strip. Items are taken by decreasing longer side and each one is
+// put where its top edge ends up lowest, then leftmost, as is or turned by 90° when its allowed
+// rotations permit. This keeps the consumed length (see Box.GetUsedLength) short. Positions breaking
+// the stability, load or balance limits of the box are skipped, as Box.PutItem does.
+//
+// It is meant for strips created by NewStrip, but fills boxes of a fixed height one after another
+// as well. The Packer selects this strategy automatically when every box and item is 2D,
+// at least one box is a strip and no algorithm was set with WithAlgorithm.
+type SkylineStrategy struct{}
+
+// NewSkylineStrategy creates a new skyline strip packing strategy.
+func NewSkylineStrategy() *SkylineStrategy {
+	return &SkylineStrategy{}
+}
+
+// Name returns the identifier for this strategy.
+func (s *SkylineStrategy) Name() string {
+	return "Skyline"
+}
+
+// Pack lays out the items box by box, longest side first.
+func (s *SkylineStrategy) Pack(ctx context.Context, boxes []*Box, items []*Item) (*Result, error) {
+	remaining := compactItems(items)
+	sort.SliceStable(remaining, func(a, b int) bool {
+		return max(remaining[a].whd[WidthAxis], remaining[a].whd[HeightAxis]) >
+			max(remaining[b].whd[WidthAxis], remaining[b].whd[HeightAxis])
+	})
+
+	sortedBoxes, result := prepareData(boxes, remaining)
+
+	for i := 0; i < len(sortedBoxes) && len(remaining) > 0; i++ {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+
+		if sortedBoxes[i] == nil {
+			continue
+		}
+
+		line := newSkyline(sortedBoxes[i])
+		rest := remaining[:0:0]
+
+		for _, item := range remaining {
+			if !line.put(item) {
+				rest = append(rest, item)
+			}
+		}
+
+		remaining = rest
+		sortedBoxes = replenishBoxType(sortedBoxes, i)
+	}
+
+	result.UnfitItems = append(result.UnfitItems, remaining...)
+	ExplainUnfit(ctx, sortedBoxes, result.UnfitItems)
+	result.Boxes = dropSpareBoxes(sortedBoxes, result.Boxes)
+
+	return result, nil
+}
+
+// hasStrip reports whether any of the boxes is a strip.
+func hasStrip(boxes []*Box) bool {
+	for _, b := range boxes {
+		if b != nil && b.strip {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skylineSegment is a horizontal part of the skyline, from x to x+w at height y.
+type skylineSegment struct {
+	x, y, w float64
+}
+
+// skyline tracks the top edge of the items in a box, from left to right.
+type skyline struct {
+	box      *Box
+	segments []skylineSegment
+}
+
+// newSkyline starts the skyline of a box. Items already in the box raise it to their top edge.
+func newSkyline(box *Box) *skyline {
+	line := &skyline{
+		box:      box,
+		segments: []skylineSegment{{w: box.width}},
+	}
+
+	if top := box.GetUsedLength(); top > 0 {
+		line.segments[0].y = top
+	}
+
+	return line
+}
+
+// put places the item where its top edge is the lowest, then the leftmost.
+// Only positions keeping the stability, load and balance limits of the box are considered.
+func (s *skyline) put(item *Item) bool {
+	if !s.box.canQuota(item) {
+		return false
+	}
+
+	var (
+		best      rect
+		bestRt    RotationType
+		bestScore = [2]float64{math.Inf(1), math.Inf(1)}
+		found     bool
+	)
+
+	for _, rt := range []RotationType{RotationTypeWhd, RotationTypeHwd} {
+		if !item.allowsRotation(rt) {
+			continue
+		}
+
+		matrix := rotationMatrix[rt]
+		w, h, d := item.whd[matrix[WidthAxis]], item.whd[matrix[HeightAxis]], item.whd[matrix[DepthAxis]]
+
+		if d > s.box.depth {
+			continue
+		}
+
+		for i, seg := range s.segments {
+			y, ok := s.fit(i, w)
+			if !ok || y+h > s.box.height+pointTolerance {
+				continue
+			}
+
+			if score := [2]float64{y + h, seg.x}; (score[0] < bestScore[0] ||
+				(score[0] == bestScore[0] && score[1] < bestScore[1])) &&
+				s.box.meetsLimitsAt(item, Pivot{seg.x, y, 0}, rt) {
+				best, bestRt, bestScore, found = rect{seg.x, y, w, h}, rt, score, true
+			}
+		}
+	}
+
+	if !found {
+		return false
+	}
+
+	item.position = Pivot{best.x, best.y, 0}
+	item.setRotationType(bestRt)
+	s.box.insert(item)
+	s.add(best)
+
+	return true
+}
+
+// fit returns the height at which an item of width w rests when its left edge is at segment i.
+func (s *skyline) fit(i int, w float64) (float64, bool) {
+	if s.segments[i].x+w > s.box.width+pointTolerance {
+		return 0, false
+	}
+
+	var y float64
+
+	for j := i; j < len(s.segments) && w > pointTolerance; j++ {
+		y = max(y, s.segments[j].y)
+		w -= s.segments[j].w
+	}
+
+	return y, true
+}
+
+// add raises the skyline under the placed item and merges neighbouring segments of the same height.
+func (s *skyline) add(r rect) {
+	top := skylineSegment{r.x, r.y + r.h, r.w}
+	end := r.x + r.w
+
+	segments := make([]skylineSegment, 0, len(s.segments)+2) //nolint:mnd // the item may split a segment in two
+	inserted := false
+
+	for _, seg := range s.segments {
+		segEnd := seg.x + seg.w
+
+		switch {
+		case segEnd <= r.x+pointTolerance:
+			segments = append(segments, seg)
+		case seg.x >= end-pointTolerance:
+			if !inserted {
+				segments, inserted = append(segments, top), true
+			}
+
+			segments = append(segments, seg)
+		default:
+			if seg.x < r.x-pointTolerance {
+				segments = append(segments, skylineSegment{seg.x, seg.y, r.x - seg.x})
+			}
+
+			if !inserted {
+				segments, inserted = append(segments, top), true
+			}
+
+			if segEnd > end+pointTolerance {
+				segments = append(segments, skylineSegment{end, seg.y, segEnd - end})
+			}
+		}
+	}
+
+	if !inserted {
+		segments = append(segments, top)
+	}
+
+	merged := segments[:1:1]
+
+	for _, seg := range segments[1:] {
+		if last := &merged[len(merged)-1]; math.Abs(last.y-seg.y) < pointTolerance {
+			last.w += seg.w
+		} else {
+			merged = append(merged, seg)
+		}
+	}
+
+	s.segments = merged
+}
@@ -0,0 +1,123 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestSkylineStrategy_Strip tests strip packing on a roll with an open-ended length.
+func TestSkylineStrategy_Strip(t *testing.T) {
+	t.Parallel()
+
+	_, items := sheetFixture()
+	roll := boxpacker3.NewStrip("roll", 100, 1000)
+	require.True(t, roll.IsStrip())
+	require.True(t, roll.Is2D())
+
+	var area float64
+	for _, it := range items {
+		area += it.GetWidth() * it.GetHeight()
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), []*boxpacker3.Box{roll}, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Len(t, result.Boxes, 1)
+	require.Empty(t, boxpacker3.ValidateResult([]*boxpacker3.Box{roll}, items, result))
+
+	length := boxpacker3.UsedLengthMetric(result)
+	require.InDelta(t, 240.0, length, 1e-9)
+	require.GreaterOrEqual(t, length, area/roll.GetWidth())
+	require.InDelta(t, area/(roll.GetWidth()*length), result.Boxes[0].GetFillRate(), 1e-9)
+
+	layered, err := boxpacker3.NewPacker(
+		boxpacker3.WithAlgorithm(boxpacker3.NewMinimizeBoxesStrategy()),
+	).PackCtx(context.Background(), []*boxpacker3.Box{roll}, items)
+	require.NoError(t, err)
+	require.Empty(t, layered.UnfitItems)
+	require.True(t, boxpacker3.MinimizeLengthGoal(result, layered))
+	require.False(t, boxpacker3.MinimizeLengthGoal(layered, result))
+}
+
+// TestSkylineStrategy_Sheets tests that boxes of a fixed length are filled one after another.
+func TestSkylineStrategy_Sheets(t *testing.T) {
+	t.Parallel()
+
+	boxes, items := sheetFixture()
+	strategy := boxpacker3.NewSkylineStrategy()
+	require.Equal(t, "Skyline", strategy.Name())
+
+	result, err := boxpacker3.NewPacker(boxpacker3.WithAlgorithm(strategy)).PackCtx(context.Background(), boxes, items)
+	require.NoError(t, err)
+	require.Empty(t, result.UnfitItems)
+	require.Empty(t, boxpacker3.ValidateResult(boxes, items, result))
+
+	for _, b := range result.Boxes {
+		require.LessOrEqual(t, b.GetUsedLength(), b.GetHeight())
+	}
+
+	// Items wider than the roll cannot be packed, unless they may be turned.
+	roll := []*boxpacker3.Box{boxpacker3.NewStrip("roll", 50, 1000)}
+	wide := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("turnable", 80, 40, 1),
+		boxpacker3.NewItem2D("fixed", 80, 40, 1, boxpacker3.WithAllowedRotations(boxpacker3.RotationTypeWhd)),
+	}
+
+	result, err = boxpacker3.NewPacker().PackCtx(context.Background(), roll, wide)
+	require.NoError(t, err)
+	require.Len(t, result.UnfitItems, 1)
+	require.Equal(t, "fixed", result.UnfitItems[0].GetID())
+	require.InDelta(t, 80.0, result.Boxes[0].GetUsedLength(), 1e-9)
+}
+
+// TestSkylineStrategy_Limits tests that strip placements keep the load and stability limits.
+func TestSkylineStrategy_Limits(t *testing.T) {
+	t.Parallel()
+
+	roll := []*boxpacker3.Box{boxpacker3.NewStrip("roll", 10, 1000)}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem2D("glass", 10, 5, 1, boxpacker3.WithFragile()),
+		boxpacker3.NewItem2D("anvil", 10, 5, 50),
+	}
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(), roll, items)
+	require.NoError(t, err)
+	require.Len(t, result.UnfitItems, 1)
+	require.Equal(t, "anvil", result.UnfitItems[0].GetID(), "Nothing rests on the glass")
+	require.Empty(t, boxpacker3.ValidateResult(roll, items, result))
+
+	_, items = sheetFixture()
+	roll = []*boxpacker3.Box{boxpacker3.NewStrip("roll", 100, 1000)}
+
+	result, err = boxpacker3.NewPacker(boxpacker3.WithStability(1)).PackCtx(context.Background(), roll, items)
+	require.NoError(t, err)
+	require.Empty(t, boxpacker3.ValidateResult(roll, items, result))
+}
+
+// TestSkylineStrategy_JSON tests that strips and their used length are encoded.
+func TestSkylineStrategy_JSON(t *testing.T) {
+	t.Parallel()
+
+	roll := boxpacker3.NewStrip("roll", 100, 1000)
+	require.True(t, roll.PutItem(boxpacker3.NewItem2D("cut", 100, 30, 1), boxpacker3.Pivot{}))
+
+	data, err := json.Marshal(roll)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"strip":true`)
+	require.Contains(t, string(data), `"usedLength":30`)
+
+	var decoded boxpacker3.Box
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.True(t, decoded.IsStrip())
+	require.InDelta(t, 30.0, decoded.GetUsedLength(), 1e-9)
+	require.InDelta(t, boxpacker3.StripLength, decoded.GetHeight(), 0)
+
+	goal, err := boxpacker3.ParseGoal("MinimizeLength")
+	require.NoError(t, err)
+	require.NotNil(t, goal)
+}
@@ -0,0 +1,145 @@
+package boxpacker3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownUnfitReason is returned when unmarshalling an unknown unfit reason name.
+var ErrUnknownUnfitReason = errors.New("boxpacker3: unknown unfit reason")
+
+// UnfitReason explains why an item ended up in Result.UnfitItems.
+type UnfitReason int
+
+const (
+	// UnfitReasonNone is reported for items that were packed, or not examined by the algorithm.
+	UnfitReasonNone UnfitReason = iota
+	// UnfitReasonTooLarge means the item does not fit into any of the boxes, even empty,
+	// in any of its allowed rotations.
+	UnfitReasonTooLarge
+	// UnfitReasonTooHeavy means the item fits into some boxes by size, but is heavier than the maximum
+	// weight of each of them, or cannot be loaded within their balance limits (see WithAxles).
+	UnfitReasonTooHeavy
+	// UnfitReasonNoSpace means the item would fit into an empty box, but no box had enough
+	// free space or remaining weight left, e.g. the boxes ran out.
+	UnfitReasonNoSpace
+	// UnfitReasonCanceled means the context was canceled before the item could be placed.
+	UnfitReasonCanceled
+)
+
+// String returns the name of the reason, e.g. "TooLarge".
+func (r UnfitReason) String() string {
+	switch r {
+	case UnfitReasonNone:
+		return "None"
+	case UnfitReasonTooLarge:
+		return "TooLarge"
+	case UnfitReasonTooHeavy:
+		return "TooHeavy"
+	case UnfitReasonNoSpace:
+		return "NoSpace"
+	case UnfitReasonCanceled:
+		return "Canceled"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(r))
+	}
+}
+
+// MarshalText encodes the reason as its name, e.g. "TooLarge".
+func (r UnfitReason) MarshalText() ([]byte, error) {
+	if r < UnfitReasonNone || r > UnfitReasonCanceled {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownUnfitReason, int(r))
+	}
+
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes a reason from its name, e.g. "TooLarge".
+func (r *UnfitReason) UnmarshalText(text []byte) error {
+	for reason := UnfitReasonNone; reason <= UnfitReasonCanceled; reason++ {
+		if reason.String() == string(text) {
+			*r = reason
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownUnfitReason, string(text))
+}
+
+// GetUnfitReason returns why the item was not packed, or UnfitReasonNone.
+func (i *Item) GetUnfitReason() UnfitReason {
+	return i.unfitReason
+}
+
+// ExplainUnfit sets the unfit reason of every item that no box in boxes holds.
+// Built-in strategies call it for Result.UnfitItems; custom PackingAlgorithm implementations
+// can call it with the boxes they were given to report reasons the same way.
+func ExplainUnfit(ctx context.Context, boxes []*Box, unfit []*Item) {
+	if len(unfit) == 0 {
+		return
+	}
+
+	// Empty copies of the boxes: one as is and one without weight and balance limits, to tell size from weight.
+	empty := make([]*Box, 0, len(boxes))
+	weightless := make([]*Box, 0, len(boxes))
+
+	for _, b := range boxes {
+		if b == nil {
+			continue
+		}
+
+		e := CopyPtr(b)
+		e.Reset()
+		empty = append(empty, e)
+
+		w := CopyPtr(e)
+		w.maxWeight = math.Inf(1)
+		w.axles = nil
+		w.cogLimits = nil
+		weightless = append(weightless, w)
+	}
+
+	for _, item := range unfit {
+		if item != nil {
+			item.unfitReason = unfitReason(ctx, empty, weightless, item)
+		}
+	}
+}
+
+func unfitReason(ctx context.Context, empty, weightless []*Box, item *Item) UnfitReason {
+	if !fitsAnyEmpty(weightless, item) {
+		return UnfitReasonTooLarge
+	}
+
+	if !fitsAnyEmpty(empty, item) {
+		return UnfitReasonTooHeavy
+	}
+
+	if ctx.Err() != nil {
+		return UnfitReasonCanceled
+	}
+
+	return UnfitReasonNoSpace
+}
+
+// fitsAnyEmpty reports whether the item can be put into one of the empty boxes, at the origin
+// or at a pivot a balanced load starts from. The boxes are emptied again after each successful attempt.
+func fitsAnyEmpty(boxes []*Box, item *Item) bool {
+	position, rotation := item.position, item.rotationType
+	defer func() { item.position, item.rotationType = position, rotation }()
+
+	for _, b := range boxes {
+		for _, pv := range b.GetExtremePoints() {
+			if b.PutItem(item, pv) {
+				b.Reset()
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
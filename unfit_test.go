@@ -0,0 +1,106 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestUnfitReason_Strategies tests that every built-in strategy explains its unfit items.
+func TestUnfitReason_Strategies(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		t.Run(strategy.String(), func(t *testing.T) {
+			t.Parallel()
+
+			boxes := boxpacker3.BoxesFromTypes(
+				boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 10, 10, 10, 50), 1),
+			)
+			items := []*boxpacker3.Item{
+				boxpacker3.NewItem("too-large", 11, 1, 1, 1),
+				boxpacker3.NewItem("locked", 1, 1, 11, 1, boxpacker3.WithoutRotation()),
+				boxpacker3.NewItem("too-heavy", 1, 1, 1, 51),
+				boxpacker3.NewItem("first", 10, 10, 10, 1),
+				boxpacker3.NewItem("second", 10, 10, 10, 1),
+			}
+
+			result, err := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy)).PackCtx(context.Background(), boxes, items)
+			require.NoError(t, err)
+
+			reasons := make(map[string]boxpacker3.UnfitReason)
+			for _, item := range result.UnfitItems {
+				reasons[item.GetID()] = item.GetUnfitReason()
+			}
+
+			require.GreaterOrEqual(t, len(reasons), 4)
+			require.Equal(t, boxpacker3.UnfitReasonTooLarge, reasons["too-large"])
+			require.Equal(t, boxpacker3.UnfitReasonTooLarge, reasons["locked"])
+			require.Equal(t, boxpacker3.UnfitReasonTooHeavy, reasons["too-heavy"])
+
+			// The equal items that are not packed ran out of boxes.
+			for _, id := range []string{"first", "second"} {
+				if reason, ok := reasons[id]; ok {
+					require.Equal(t, boxpacker3.UnfitReasonNoSpace, reason)
+				}
+			}
+
+			for _, box := range result.Boxes {
+				for _, item := range box.GetItems() {
+					require.Equal(t, boxpacker3.UnfitReasonNone, item.GetUnfitReason())
+				}
+			}
+		})
+	}
+}
+
+// TestExplainUnfit tests the context reason and that explaining keeps the item placement untouched.
+func TestExplainUnfit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	box := boxpacker3.NewBox("box", 10, 10, 10, 50)
+	require.True(t, box.PutItem(boxpacker3.NewItem("packed", 10, 10, 10, 1), boxpacker3.Pivot{}))
+
+	item := boxpacker3.NewItem("item", 1, 2, 3, 1)
+	boxpacker3.ExplainUnfit(ctx, []*boxpacker3.Box{box}, []*boxpacker3.Item{item, nil})
+
+	require.Equal(t, boxpacker3.UnfitReasonCanceled, item.GetUnfitReason())
+	require.Equal(t, boxpacker3.Pivot{}, item.GetPosition())
+	require.Equal(t, boxpacker3.RotationTypeWhd, item.GetRotationType())
+	require.Len(t, box.GetItems(), 1)
+
+	boxpacker3.ExplainUnfit(context.Background(), nil, []*boxpacker3.Item{item})
+	require.Equal(t, boxpacker3.UnfitReasonTooLarge, item.GetUnfitReason())
+	require.Equal(t, "TooLarge", item.GetUnfitReason().String())
+}
+
+// TestUnfitReason_JSON tests that unfit reasons survive a JSON round trip.
+func TestUnfitReason_JSON(t *testing.T) {
+	t.Parallel()
+
+	result, err := boxpacker3.NewPacker().PackCtx(context.Background(),
+		[]*boxpacker3.Box{boxpacker3.NewBox("box", 10, 10, 10, 5)},
+		[]*boxpacker3.Item{boxpacker3.NewItem("heavy", 1, 1, 1, 6), boxpacker3.NewItem("ok", 1, 1, 1, 1)},
+	)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"unfitReason":"TooHeavy"`)
+	require.Equal(t, 1, strings.Count(string(data), "unfitReason"), "Packed items omit the reason")
+
+	var restored boxpacker3.Result
+	require.NoError(t, json.Unmarshal(data, &restored))
+	require.Equal(t, boxpacker3.UnfitReasonTooHeavy, restored.UnfitItems[0].GetUnfitReason())
+
+	var reason boxpacker3.UnfitReason
+	require.ErrorIs(t, reason.UnmarshalText([]byte("Magic")), boxpacker3.ErrUnknownUnfitReason)
+}
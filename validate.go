@@ -0,0 +1,422 @@
+package boxpacker3
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// validationTolerance absorbs floating point noise in geometric and weight checks.
+const validationTolerance = 1e-6
+
+// ViolationType identifies the invariant broken by a packing result.
+type ViolationType int
+
+const (
+	// ViolationOverlap means two items in the same box intersect.
+	ViolationOverlap ViolationType = iota
+	// ViolationOutOfBounds means an item sticks out of its box.
+	ViolationOutOfBounds
+	// ViolationOverweight means the items in a box exceed its maximum weight.
+	ViolationOverweight
+	// ViolationDuplicateItem means an item appears more often in the result than in the input.
+	ViolationDuplicateItem
+	// ViolationMissingItem means an input item is neither packed nor reported as unfit.
+	ViolationMissingItem
+	// ViolationUnknownItem means the result contains an item that is not in the input.
+	ViolationUnknownItem
+	// ViolationItemMismatch means a result item differs in size or weight from the input item with the same id.
+	ViolationItemMismatch
+	// ViolationRotationNotAllowed means an item is placed in a rotation it does not allow.
+	ViolationRotationNotAllowed
+	// ViolationUnsupported means an item does not have the minimum support required by its box.
+	ViolationUnsupported
+	// ViolationOverloaded means the weight resting on an item exceeds its maximum load.
+	ViolationOverloaded
+	// ViolationUnknownBox means the result contains a box that is not in the input.
+	ViolationUnknownBox
+	// ViolationBoxLimit means a box, or a box type, is used more often than available.
+	ViolationBoxLimit
+	// ViolationAxleOverload means an axle of a box carries more than its maximum load, or is lifted
+	// because the centre of gravity is outside the wheelbase (see WithAxles).
+	ViolationAxleOverload
+	// ViolationCenterOfGravity means the centre of gravity of a box is outside its envelope
+	// (see WithCenterOfGravityLimits).
+	ViolationCenterOfGravity
+	// ViolationOverhang means an item stands in the overhang of a pallet rather than over its deck
+	// (see WithOverhang).
+	ViolationOverhang
+)
+
+// String returns the name of the violation type, e.g. "Overlap".
+func (v ViolationType) String() string {
+	switch v {
+	case ViolationOverlap:
+		return "Overlap"
+	case ViolationOutOfBounds:
+		return "OutOfBounds"
+	case ViolationOverweight:
+		return "Overweight"
+	case ViolationDuplicateItem:
+		return "DuplicateItem"
+	case ViolationMissingItem:
+		return "MissingItem"
+	case ViolationUnknownItem:
+		return "UnknownItem"
+	case ViolationItemMismatch:
+		return "ItemMismatch"
+	case ViolationRotationNotAllowed:
+		return "RotationNotAllowed"
+	case ViolationUnsupported:
+		return "Unsupported"
+	case ViolationOverloaded:
+		return "Overloaded"
+	case ViolationUnknownBox:
+		return "UnknownBox"
+	case ViolationBoxLimit:
+		return "BoxLimit"
+	case ViolationAxleOverload:
+		return "AxleOverload"
+	case ViolationCenterOfGravity:
+		return "CenterOfGravity"
+	case ViolationOverhang:
+		return "Overhang"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(v))
+	}
+}
+
+// Violation describes a single broken invariant of a packing result.
+type Violation struct {
+	Type ViolationType
+	// BoxIndex is the index of the box in Result.Boxes, or -1 when the violation is not tied to a box.
+	BoxIndex int
+	BoxID    string
+	ItemIDs  []string
+	Detail   string
+}
+
+// Error implements the error interface, so a violation can be returned or wrapped as an error.
+func (v Violation) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(v.Type.String())
+
+	if v.BoxIndex >= 0 {
+		fmt.Fprintf(&sb, ": box %q (#%d)", v.BoxID, v.BoxIndex)
+	}
+
+	if len(v.ItemIDs) > 0 {
+		fmt.Fprintf(&sb, ": items %q", v.ItemIDs)
+	}
+
+	if v.Detail != "" {
+		sb.WriteString(": " + v.Detail)
+	}
+
+	return sb.String()
+}
+
+// ValidateResult checks a packing result against the boxes and items it was produced from
+// and returns every violation found, or nil for a valid result.
+//
+// Boxes and items are matched by id, so results produced by any PackingAlgorithm or loaded
+// from storage (see Result.UnmarshalJSON) can be checked. The following invariants are verified:
+//   - items in a box do not overlap and stay within its bounds;
+//   - boxes respect their maximum weight, minimum support (WithMinSupport) and the item load limits (WithMaxLoad);
+//   - axles are not overloaded and the centre of gravity is within its envelope (WithAxles, WithCenterOfGravityLimits);
+//   - items keep their size and weight and are placed in allowed rotations;
+//   - every input item is either packed or unfit, exactly once;
+//   - boxes come from the input and are not used more often than available (see BoxType).
+func ValidateResult(boxes []*Box, items []*Item, result *Result) []Violation {
+	if result == nil {
+		result = &Result{}
+	}
+
+	v := &validator{
+		inputItems: make(map[string][]*Item, len(items)),
+		inputBoxes: make(map[string][]*Box, len(boxes)),
+		seenItems:  make(map[*Item]bool, len(items)),
+		seenIDs:    make(map[string]int, len(items)),
+	}
+
+	for _, it := range items {
+		if it != nil {
+			v.inputItems[it.id] = append(v.inputItems[it.id], it)
+		}
+	}
+
+	for _, b := range boxes {
+		if b != nil {
+			v.inputBoxes[b.id] = append(v.inputBoxes[b.id], b)
+		}
+	}
+
+	v.checkBoxUsage(result.Boxes)
+
+	for i, b := range result.Boxes {
+		if b == nil {
+			continue
+		}
+
+		v.checkBox(i, b)
+
+		for _, it := range b.items {
+			v.checkItem(i, b, it)
+		}
+	}
+
+	for _, it := range result.UnfitItems {
+		v.checkItem(-1, nil, it)
+	}
+
+	v.checkMissing(items)
+
+	return v.violations
+}
+
+type validator struct {
+	inputItems map[string][]*Item
+	inputBoxes map[string][]*Box
+	seenItems  map[*Item]bool
+	seenIDs    map[string]int
+	violations []Violation
+}
+
+func (v *validator) add(t ViolationType, boxIndex int, box *Box, detail string, items ...*Item) {
+	violation := Violation{Type: t, BoxIndex: boxIndex, Detail: detail}
+
+	if box != nil {
+		violation.BoxID = box.id
+	}
+
+	for _, it := range items {
+		violation.ItemIDs = append(violation.ItemIDs, it.id)
+	}
+
+	v.violations = append(v.violations, violation)
+}
+
+// checkBoxUsage verifies that every result box comes from the input and that supplies are respected.
+func (v *validator) checkBoxUsage(boxes []*Box) {
+	plainUsed := make(map[string]int)
+	typeUsed := make(map[*BoxType]int)
+
+	for i, b := range boxes {
+		if b == nil {
+			continue
+		}
+
+		if !v.knownBox(b) {
+			v.add(ViolationUnknownBox, i, b, "")
+
+			continue
+		}
+
+		// The supply is taken from the input, a result cannot raise the quantity of its own box type.
+		bt := v.inputType(b)
+		if bt == nil {
+			plainUsed[b.id]++
+
+			if available := v.plainBoxes(b.id); plainUsed[b.id] > available {
+				v.add(ViolationBoxLimit, i, b, fmt.Sprintf("used %d times, %d available", plainUsed[b.id], available))
+			}
+
+			continue
+		}
+
+		typeUsed[bt]++
+
+		if !bt.allows(typeUsed[bt] - 1) {
+			v.add(ViolationBoxLimit, i, b, fmt.Sprintf("box type used %d times, %d available", typeUsed[bt], bt.quantity))
+		}
+	}
+}
+
+// knownBox reports whether an input box with the same id has the same size and weight limit.
+func (v *validator) knownBox(b *Box) bool {
+	for _, in := range v.inputBoxes[b.id] {
+		if floatsEqual(in.width, b.width) && floatsEqual(in.height, b.height) &&
+			floatsEqual(in.depth, b.depth) && floatsEqual(in.maxWeight, b.maxWeight) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inputType returns the type of the input box matching a result box opened from a box type,
+// or nil for a one-off box.
+func (v *validator) inputType(b *Box) *BoxType {
+	if b.boxType == nil {
+		return nil
+	}
+
+	for _, in := range v.inputBoxes[b.id] {
+		if in.boxType != nil && floatsEqual(in.width, b.width) && floatsEqual(in.height, b.height) &&
+			floatsEqual(in.depth, b.depth) && floatsEqual(in.maxWeight, b.maxWeight) {
+			return in.boxType
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) plainBoxes(id string) int {
+	n := 0
+
+	for _, in := range v.inputBoxes[id] {
+		if in.boxType == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// checkBox verifies the placement invariants of the items inside a box.
+func (v *validator) checkBox(i int, b *Box) {
+	var weight float64
+
+	for j, it := range b.items {
+		if it == nil {
+			continue
+		}
+
+		weight += it.weight
+
+		for _, other := range b.items[j+1:] {
+			if it.Intersect(other) {
+				v.add(ViolationOverlap, i, b, "", it, other)
+			}
+		}
+
+		pos := it.position
+		dim := it.GetDimension()
+		size := [3]float64{b.width, b.height, b.depth}
+
+		for axis := range size {
+			if pos[axis] < -validationTolerance || pos[axis]+dim[axis] > size[axis]+validationTolerance {
+				v.add(ViolationOutOfBounds, i, b, fmt.Sprintf("position %v, dimension %v", pos, dim), it)
+
+				break
+			}
+		}
+
+		if !it.allowsRotation(it.rotationType) {
+			v.add(ViolationRotationNotAllowed, i, b, it.rotationType.String(), it)
+		}
+
+		if !b.overDeck(it) {
+			v.add(ViolationOverhang, i, b, fmt.Sprintf("position %v, dimension %v", pos, dim), it)
+		}
+
+		if b.minSupport > 0 && !b.isSupported(it) {
+			v.add(ViolationUnsupported, i, b,
+				fmt.Sprintf("support %.4f < %.4f", b.GetSupportRatio(it), b.minSupport), it)
+		}
+	}
+
+	if weight > b.maxWeight+validationTolerance {
+		v.add(ViolationOverweight, i, b, fmt.Sprintf("weight %g > %g", weight, b.maxWeight))
+	}
+
+	loads := itemLoads(b.items)
+
+	for _, it := range b.items {
+		if it != nil && it.loadLimited && loads[it] > it.maxLoad+validationTolerance {
+			v.add(ViolationOverloaded, i, b, fmt.Sprintf("load %g > %g", loads[it], it.maxLoad), it)
+		}
+	}
+
+	v.checkBalance(i, b, weight)
+}
+
+// checkBalance verifies the axle loads and the centre of gravity of a box in container loading mode.
+func (v *validator) checkBalance(i int, b *Box, weight float64) {
+	cog := centerOfGravity(b.items, nil)
+
+	for k, load := range axleLoads(b.axles, weight, cog) {
+		switch {
+		case load > b.axles[k].MaxLoad+validationTolerance:
+			v.add(ViolationAxleOverload, i, b, fmt.Sprintf("axle %d load %g > %g", k, load, b.axles[k].MaxLoad))
+		case load < -validationTolerance:
+			v.add(ViolationAxleOverload, i, b, fmt.Sprintf("axle %d lifted, load %g < 0", k, load))
+		}
+	}
+
+	if b.cogLimits != nil && weight > 0 && b.cogDistance(cog) > validationTolerance {
+		v.add(ViolationCenterOfGravity, i, b,
+			fmt.Sprintf("centre of gravity %v outside %v..%v", cog, b.cogLimits[0], b.cogLimits[1]))
+	}
+}
+
+// checkItem accounts for an item of the result, packed into box i or unfit (i == -1).
+func (v *validator) checkItem(i int, b *Box, it *Item) {
+	if it == nil {
+		return
+	}
+
+	inputs, known := v.inputItems[it.id]
+
+	switch {
+	case v.seenItems[it]:
+		v.add(ViolationDuplicateItem, i, b, "same item reported twice", it)
+
+		return
+	case !known:
+		v.add(ViolationUnknownItem, i, b, "", it)
+
+		return
+	}
+
+	v.seenItems[it] = true
+	v.seenIDs[it.id]++
+
+	if v.seenIDs[it.id] > len(inputs) {
+		v.add(ViolationDuplicateItem, i, b, fmt.Sprintf("%d in result, %d in input", v.seenIDs[it.id], len(inputs)), it)
+
+		return
+	}
+
+	in := inputs[0]
+	if !floatsEqual(in.weight, it.weight) || !sameSize(in.whd, it.whd) {
+		v.add(ViolationItemMismatch, i, b,
+			fmt.Sprintf("size %v weight %g, input size %v weight %g", it.whd, it.weight, in.whd, in.weight), it)
+	}
+}
+
+func (v *validator) checkMissing(items []*Item) {
+	reported := make(map[string]bool, len(items))
+
+	for _, it := range items {
+		if it == nil || reported[it.id] {
+			continue
+		}
+
+		reported[it.id] = true
+
+		if missing := len(v.inputItems[it.id]) - v.seenIDs[it.id]; missing > 0 {
+			v.add(ViolationMissingItem, -1, nil, fmt.Sprintf("%d missing", missing), it)
+		}
+	}
+}
+
+// sameSize reports whether two items have the same dimensions in any orientation.
+func sameSize(a, b Dimension) bool {
+	sa, sb := a[:], b[:]
+	slices.Sort(sa)
+	slices.Sort(sb)
+
+	for i := range sa {
+		if !floatsEqual(sa[i], sb[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func floatsEqual(a, b float64) bool {
+	return a == b || (a-b < validationTolerance && b-a < validationTolerance)
+}
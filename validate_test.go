@@ -0,0 +1,156 @@
+package boxpacker3_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bavix/boxpacker3"
+)
+
+// TestValidateResult_BuiltinStrategies tests that results of every built-in strategy are valid.
+func TestValidateResult_BuiltinStrategies(t *testing.T) {
+	t.Parallel()
+
+	boxes := append(
+		boxpacker3.BoxesFromTypes(boxpacker3.NewBoxType(boxpacker3.NewBox("carton", 40, 40, 40, 30, boxpacker3.WithMinSupport(0.6)), 3)),
+		boxpacker3.NewBox("crate", 100, 60, 80, 200),
+	)
+
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("glass", 20, 20, 20, 2, boxpacker3.WithFragile()),
+		boxpacker3.NewItem("tv", 50, 40, 10, 15, boxpacker3.WithThisSideUp()),
+		boxpacker3.NewItem("tool", 30, 10, 10, 25),
+		boxpacker3.NewItem("tool", 30, 10, 10, 25),
+		boxpacker3.NewItem("book", 20, 5, 15, 1),
+		boxpacker3.NewItem("huge", 500, 500, 500, 1),
+	}
+
+	for _, strategy := range boxpacker3.PackingStrategies() {
+		result, err := boxpacker3.NewPacker(boxpacker3.WithStrategy(strategy)).PackCtx(context.Background(), boxes, items)
+		require.NoError(t, err)
+		require.Empty(t, boxpacker3.ValidateResult(boxes, items, result), strategy.String())
+	}
+}
+
+// TestValidateResult_Violations tests that broken results stored as JSON are reported.
+func TestValidateResult_Violations(t *testing.T) {
+	t.Parallel()
+
+	boxes := []*boxpacker3.Box{
+		boxpacker3.NewBox("box", 10, 10, 10, 5),
+	}
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("a", 5, 5, 5, 3),
+		boxpacker3.NewItem("b", 5, 5, 5, 3),
+		boxpacker3.NewItem("c", 1, 1, 1, 1),
+	}
+
+	// a and b overlap and are too heavy together, b sticks out of the box, c is missing,
+	// a is reported twice, d is unknown and the box is used twice although it exists once.
+	data := `{"version": 1, "boxes": [
+		{"id": "box", "width": 10, "height": 10, "depth": 10, "maxWeight": 5, "items": [
+			{"id": "a", "width": 5, "height": 5, "depth": 5, "weight": 3, "position": [0, 0, 0]},
+			{"id": "b", "width": 5, "height": 5, "depth": 5, "weight": 3, "position": [2, -1, 2]}
+		]},
+		{"id": "box", "width": 10, "height": 10, "depth": 10, "maxWeight": 5, "items": [
+			{"id": "d", "width": 1, "height": 1, "depth": 1, "weight": 1, "position": [0, 0, 0]}
+		]},
+		{"id": "bag", "width": 10, "height": 10, "depth": 10, "maxWeight": 5}
+	], "unfitItems": [
+		{"id": "a", "width": 5, "height": 5, "depth": 6, "weight": 3}
+	]}`
+
+	var result boxpacker3.Result
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+
+	violations := boxpacker3.ValidateResult(boxes, items, &result)
+
+	got := make(map[boxpacker3.ViolationType][]string)
+	for _, v := range violations {
+		require.NotEmpty(t, v.Error())
+
+		got[v.Type] = append(got[v.Type], v.BoxID)
+
+		if v.Type == boxpacker3.ViolationOverlap {
+			require.Equal(t, []string{"a", "b"}, v.ItemIDs)
+			require.Equal(t, 0, v.BoxIndex)
+		}
+	}
+
+	require.Equal(t, map[boxpacker3.ViolationType][]string{
+		boxpacker3.ViolationOverlap:       {"box"},
+		boxpacker3.ViolationOutOfBounds:   {"box"},
+		boxpacker3.ViolationOverweight:    {"box"},
+		boxpacker3.ViolationBoxLimit:      {"box"},
+		boxpacker3.ViolationUnknownBox:    {"bag"},
+		boxpacker3.ViolationUnknownItem:   {"box"},
+		boxpacker3.ViolationDuplicateItem: {""},
+		boxpacker3.ViolationMissingItem:   {""},
+	}, got)
+
+	require.Equal(t, "Overlap", boxpacker3.ViolationOverlap.String())
+}
+
+// TestValidateResult_ItemConstraints tests violations of item rotation, support, load and size.
+func TestValidateResult_ItemConstraints(t *testing.T) {
+	t.Parallel()
+
+	box := boxpacker3.NewBox("box", 10, 10, 10, 100, boxpacker3.WithMinSupport(1))
+	glass := boxpacker3.NewItem("glass", 4, 2, 4, 1, boxpacker3.WithFragile())
+	tv := boxpacker3.NewItem("tv", 2, 4, 2, 1, boxpacker3.WithoutRotation())
+	bottle := boxpacker3.NewItem("bottle", 1, 3, 1, 1)
+
+	data := `{"version": 1, "boxes": [
+		{"id": "box", "width": 10, "height": 10, "depth": 10, "maxWeight": 100, "minSupport": 1, "items": [
+			{"id": "glass", "width": 4, "height": 2, "depth": 4, "weight": 1, "maxLoad": 0, "position": [0, 0, 0]},
+			{"id": "tv", "width": 2, "height": 4, "depth": 2, "weight": 1, "allowedRotations": ["WHD"],
+				"rotation": "HWD", "position": [0, 2, 0]},
+			{"id": "bottle", "width": 1, "height": 5, "depth": 1, "weight": 1, "position": [8, 3, 8]}
+		]}
+	], "unfitItems": []}`
+
+	var result boxpacker3.Result
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+
+	types := make([]boxpacker3.ViolationType, 0)
+	for _, v := range boxpacker3.ValidateResult([]*boxpacker3.Box{box}, []*boxpacker3.Item{glass, tv, bottle}, &result) {
+		types = append(types, v.Type)
+	}
+
+	require.ElementsMatch(t, []boxpacker3.ViolationType{
+		boxpacker3.ViolationRotationNotAllowed, // tv
+		boxpacker3.ViolationUnsupported,        // bottle floats
+		boxpacker3.ViolationOverloaded,         // glass carries tv
+		boxpacker3.ViolationItemMismatch,       // bottle grew
+	}, types)
+}
+
+// TestValidateResult_ForgedBoxType tests that the box type supply is taken from the input, not from the result.
+func TestValidateResult_ForgedBoxType(t *testing.T) {
+	t.Parallel()
+
+	boxes := boxpacker3.BoxesFromTypes(boxpacker3.NewBoxType(boxpacker3.NewBox("box", 10, 10, 10, 100), 1))
+	items := []*boxpacker3.Item{
+		boxpacker3.NewItem("a", 10, 10, 10, 1),
+		boxpacker3.NewItem("b", 10, 10, 10, 1),
+	}
+
+	// Both boxes claim an unlimited supply of the type, while the input has a single box.
+	data := `{"version": 1, "boxes": [
+		{"id": "box", "width": 10, "height": 10, "depth": 10, "maxWeight": 100, "type": {"id": "box", "quantity": 0},
+			"items": [{"id": "a", "width": 10, "height": 10, "depth": 10, "weight": 1, "position": [0, 0, 0]}]},
+		{"id": "box", "width": 10, "height": 10, "depth": 10, "maxWeight": 100, "type": {"id": "box", "quantity": 0},
+			"items": [{"id": "b", "width": 10, "height": 10, "depth": 10, "weight": 1, "position": [0, 0, 0]}]}
+	], "unfitItems": []}`
+
+	var result boxpacker3.Result
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+
+	violations := boxpacker3.ValidateResult(boxes, items, &result)
+	require.Len(t, violations, 1)
+	require.Equal(t, boxpacker3.ViolationBoxLimit, violations[0].Type)
+	require.Equal(t, 1, violations[0].BoxIndex)
+}